@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/authorizer"
+	"github.com/jfelipearaujo-org/lambda-register/internal/database"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+	"github.com/jfelipearaujo-org/lambda-register/internal/router"
+	"github.com/jfelipearaujo-org/lambda-register/internal/token"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func init() {
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}
+
+	handler := slog.NewTextHandler(os.Stdout, opts)
+
+	log := slog.New(handler)
+
+	slog.SetDefault(log)
+}
+
+func authorize(db *database.Database, a authorizer.Authorizer) func(ctx context.Context, req authorizer.Request) (events.APIGatewayCustomAuthorizerResponse, error) {
+	return func(ctx context.Context, req authorizer.Request) (events.APIGatewayCustomAuthorizerResponse, error) {
+		slog.Info("received an authorization request", "type", req.Type, "method_arn", req.MethodArn)
+
+		ctx, cancel := router.WithDeadlineMargin(ctx, router.DEADLINE_MARGIN)
+		defer cancel()
+
+		if err := db.RefreshIfStale(ctx); err != nil {
+			slog.Error("error refreshing the database credentials, keeping the current connections", "error", err)
+		}
+
+		return a.Authorize(ctx, req)
+	}
+}
+
+func main() {
+	ctx := context.Background()
+
+	timeProvider := providers.NewTimeProvider(time.Now)
+
+	dbConfig, err := database.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the database configuration", "error", err)
+		os.Exit(1)
+	}
+
+	credentials, err := database.NewCredentialsFromConfig(dbConfig)
+	if err != nil {
+		slog.Error("error loading the database credentials", "error", err)
+		os.Exit(1)
+	}
+
+	db, err := database.NewDatabaseFromConfig(ctx, dbConfig, credentials, timeProvider)
+	if err != nil {
+		slog.Error("error connecting to the database", "error", err)
+		os.Exit(1)
+	}
+
+	tokenConfig, err := token.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the token configuration", "error", err)
+		os.Exit(1)
+	}
+
+	jwt, err := token.NewToken(tokenConfig, timeProvider, db)
+	if err != nil {
+		slog.Error("error creating the token verifier", "error", err)
+		os.Exit(1)
+	}
+
+	lambda.Start(authorize(db, authorizer.NewAuthorizer(jwt)))
+}
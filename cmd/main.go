@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
 	_ "embed"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
+	"github.com/jfelipearaujo-org/lambda-register/internal/cpf"
 	"github.com/jfelipearaujo-org/lambda-register/internal/database"
 	"github.com/jfelipearaujo-org/lambda-register/internal/handlers"
 	"github.com/jfelipearaujo-org/lambda-register/internal/hashs"
+	"github.com/jfelipearaujo-org/lambda-register/internal/policy"
+	policy_interface "github.com/jfelipearaujo-org/lambda-register/internal/policy/interfaces"
 	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+	"github.com/jfelipearaujo-org/lambda-register/internal/pwned"
 	"github.com/jfelipearaujo-org/lambda-register/internal/router"
 	"github.com/jfelipearaujo-org/lambda-register/internal/token"
 
@@ -29,23 +35,126 @@ func init() {
 	slog.SetDefault(log)
 }
 
-func routerReq(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	slog.Info("received a request", "path", req.Path, "method", req.HTTPMethod)
+// newRouter builds the dependencies once per Lambda instance, they are reused
+// by every invocation the instance serves
+func newRouter(timeProvider *providers.TimeProvider, db *database.Database) (*router.Router, error) {
+	cpf.LoadDenylistFromEnv()
 
-	timeProvider := providers.NewTimeProvider(time.Now)
-	db := database.NewDatabaseFromConnStr(timeProvider)
-	hasher := hashs.NewHasher()
-	jwt := token.NewToken()
+	hashConfig, err := hashs.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the hash configuration", "error", err)
+		return nil, err
+	}
+
+	hasher, err := hashs.NewHasher(hashConfig)
+	if err != nil {
+		slog.Error("error creating the password hasher", "error", err)
+		return nil, err
+	}
+
+	policyConfig, err := policy.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the password policy configuration", "error", err)
+		return nil, err
+	}
+
+	pwnedConfig, err := pwned.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the pwned passwords configuration", "error", err)
+		return nil, err
+	}
+
+	pwnedStore, err := pwned.NewStore(pwnedConfig, db)
+	if err != nil {
+		slog.Error("error creating the pwned passwords store", "error", err)
+		return nil, err
+	}
 
-	handler := handlers.NewHandler(db, hasher, jwt)
+	var rules []policy_interface.Rule
+	if pwnedStore != nil {
+		rules = append(rules, pwned.NewChecker(pwnedStore, pwnedConfig.MinCount))
+	}
 
-	if req.Path == "/register" && req.HTTPMethod == "POST" {
-		return handler.CrateUser(req)
+	passwordPolicy, err := policy.NewPolicy(policyConfig, rules...)
+	if err != nil {
+		slog.Error("error creating the password policy", "error", err)
+		return nil, err
 	}
 
-	return router.MethodNotAllowed(), nil
+	tokenConfig, err := token.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the token configuration", "error", err)
+		return nil, err
+	}
+
+	jwt, err := token.NewToken(tokenConfig, timeProvider, db)
+	if err != nil {
+		slog.Error("error creating the token signer", "error", err)
+		return nil, err
+	}
+
+	introspectionClients, err := handlers.NewIntrospectionClientsFromEnv()
+	if err != nil {
+		slog.Error("error loading the introspection clients", "error", err)
+		return nil, err
+	}
+
+	handler := handlers.NewHandler(db, hasher, jwt, timeProvider, passwordPolicy, introspectionClients)
+
+	r := router.NewRouter()
+	r.Handle(http.MethodPost, "/register", handler.CrateUser)
+	r.Handle(http.MethodPost, "/login", handler.Login)
+	r.Handle(http.MethodPost, "/customers/{id}/upgrade", handler.UpgradeUser)
+	r.Handle(http.MethodPost, "/token/refresh", handler.RefreshToken)
+	r.Handle(http.MethodPost, "/logout", handler.Logout)
+	r.Handle(http.MethodPost, "/token/introspect", handler.Introspect)
+	r.Handle(http.MethodGet, "/.well-known/jwks.json", handler.Jwks)
+
+	return r, nil
+}
+
+func routerReq(db *database.Database, r *router.Router) func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		slog.Info("received a request", "path", req.Path, "method", req.HTTPMethod)
+
+		ctx, cancel := router.WithDeadlineMargin(ctx, router.DEADLINE_MARGIN)
+		defer cancel()
+
+		if err := db.RefreshIfStale(ctx); err != nil {
+			slog.Error("error refreshing the database credentials, keeping the current connections", "error", err)
+		}
+
+		return r.Route(ctx, req)
+	}
 }
 
 func main() {
-	lambda.Start(routerReq)
+	ctx := context.Background()
+
+	timeProvider := providers.NewTimeProvider(time.Now)
+
+	dbConfig, err := database.NewConfigFromEnv()
+	if err != nil {
+		slog.Error("error loading the database configuration", "error", err)
+		os.Exit(1)
+	}
+
+	credentials, err := database.NewCredentialsFromConfig(dbConfig)
+	if err != nil {
+		slog.Error("error loading the database credentials", "error", err)
+		os.Exit(1)
+	}
+
+	db, err := database.NewDatabaseFromConfig(ctx, dbConfig, credentials, timeProvider)
+	if err != nil {
+		slog.Error("error connecting to the database", "error", err)
+		os.Exit(1)
+	}
+
+	r, err := newRouter(timeProvider, db)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	lambda.Start(routerReq(db, r))
 }
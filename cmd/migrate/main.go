@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/database"
+	"github.com/jfelipearaujo-org/lambda-register/internal/migrations"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func init() {
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}
+
+	handler := slog.NewTextHandler(os.Stdout, opts)
+
+	log := slog.New(handler)
+
+	slog.SetDefault(log)
+}
+
+func migrate(ctx context.Context, req migrations.Request) (migrations.Response, error) {
+	slog.Info("received a migration request", "command", req.Command, "steps", req.Steps)
+
+	conn, err := database.NewConnFromEnv(ctx)
+	if err != nil {
+		slog.Error("error opening the database connection", "error", err)
+		return migrations.Response{}, err
+	}
+	defer conn.Close()
+
+	embedded, err := migrations.Embedded()
+	if err != nil {
+		slog.Error("error loading the migrations", "error", err)
+		return migrations.Response{}, err
+	}
+
+	migrator := migrations.NewMigrator(conn, providers.NewTimeProvider(time.Now), embedded)
+
+	resp, err := migrator.Run(ctx, req)
+	if err != nil {
+		slog.Error("error running the migrations", "command", req.Command, "error", err)
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// main runs as a Lambda when started by the Lambda runtime, so the migrations
+// can be invoked from the deploy pipeline, and as a command line tool otherwise:
+//
+//	migrate [-steps N] up|down|status
+func main() {
+	if os.Getenv("AWS_LAMBDA_RUNTIME_API") != "" {
+		lambda.Start(migrate)
+		return
+	}
+
+	steps := flag.Int("steps", 1, "number of migrations to revert with the down command")
+	flag.Parse()
+
+	resp, err := migrate(context.Background(), migrations.Request{
+		Command: flag.Arg(0),
+		Steps:   *steps,
+	})
+	if err != nil {
+		os.Exit(1)
+	}
+
+	out, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		os.Exit(1)
+	}
+
+	fmt.Println(string(out))
+}
@@ -0,0 +1,101 @@
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/jfelipearaujo-org/lambda-register/internal/router"
+	"github.com/jfelipearaujo-org/lambda-register/internal/token/interfaces"
+)
+
+const (
+	REQUEST_TYPE_TOKEN   = "TOKEN"
+	REQUEST_TYPE_REQUEST = "REQUEST"
+)
+
+// API Gateway only answers with 401 when the authorizer fails with this exact message
+var ErrUnauthorized = errors.New("Unauthorized")
+
+// Request accepts both the TOKEN and the REQUEST authorizer events, the TOKEN
+// one only fills the type, the method ARN and the authorization token
+type Request struct {
+	events.APIGatewayCustomAuthorizerRequestTypeRequest
+	AuthorizationToken string `json:"authorizationToken"`
+}
+
+type Authorizer struct {
+	jwt interfaces.Token
+}
+
+func NewAuthorizer(jwt interfaces.Token) Authorizer {
+	return Authorizer{
+		jwt: jwt,
+	}
+}
+
+func (a Authorizer) Authorize(ctx context.Context, req Request) (events.APIGatewayCustomAuthorizerResponse, error) {
+	tokenString, ok := getToken(req)
+	if !ok {
+		slog.Info("authorization token not found", "type", req.Type)
+		return events.APIGatewayCustomAuthorizerResponse{}, ErrUnauthorized
+	}
+
+	claims, err := a.jwt.VerifyJwtToken(ctx, tokenString)
+	if err != nil && router.TimedOut(ctx, err) {
+		slog.Error("authorization token verification timed out", "type", req.Type, "error", err)
+		return events.APIGatewayCustomAuthorizerResponse{}, errors.Join(ctx.Err(), err)
+	}
+
+	if err != nil {
+		slog.Info("authorization token rejected", "type", req.Type, "error", err)
+		return events.APIGatewayCustomAuthorizerResponse{}, ErrUnauthorized
+	}
+
+	return events.APIGatewayCustomAuthorizerResponse{
+		PrincipalID: claims.Subject,
+		PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+			Version: "2012-10-17",
+			Statement: []events.IAMPolicyStatement{
+				{
+					Action:   []string{"execute-api:Invoke"},
+					Effect:   "Allow",
+					Resource: []string{resourceFromMethodArn(req.MethodArn)},
+				},
+			},
+		},
+		Context: map[string]interface{}{
+			"customer_id":  claims.Subject,
+			"is_anonymous": claims.IsAnonymous,
+		},
+	}, nil
+}
+
+func getToken(req Request) (string, bool) {
+	switch req.Type {
+	case REQUEST_TYPE_TOKEN:
+		return router.ParseBearerToken(req.AuthorizationToken)
+	case REQUEST_TYPE_REQUEST:
+		return router.GetBearerToken(events.APIGatewayProxyRequest{
+			Headers:           req.Headers,
+			MultiValueHeaders: req.MultiValueHeaders,
+		})
+	}
+
+	return "", false
+}
+
+// resourceFromMethodArn widens the method ARN to every method and resource of
+// the same API stage, so a cached policy is valid for the whole API.
+// e.g. arn:aws:execute-api:us-east-1:123456789012:abcdef/prod/GET/orders
+// becomes arn:aws:execute-api:us-east-1:123456789012:abcdef/prod/*/*
+func resourceFromMethodArn(methodArn string) string {
+	parts := strings.SplitN(methodArn, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return methodArn
+	}
+
+	return parts[0] + "/" + parts[1] + "/*/*"
+}
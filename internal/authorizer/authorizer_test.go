@@ -0,0 +1,259 @@
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	token_interface_mock "github.com/jfelipearaujo-org/lambda-register/internal/token/interfaces/mocks"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const methodArn = "arn:aws:execute-api:us-east-1:123456789012:abcdef/prod/GET/orders/1"
+
+func newAllowResponse(subject string, isAnonymous bool) events.APIGatewayCustomAuthorizerResponse {
+	return events.APIGatewayCustomAuthorizerResponse{
+		PrincipalID: subject,
+		PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+			Version: "2012-10-17",
+			Statement: []events.IAMPolicyStatement{
+				{
+					Action:   []string{"execute-api:Invoke"},
+					Effect:   "Allow",
+					Resource: []string{"arn:aws:execute-api:us-east-1:123456789012:abcdef/prod/*/*"},
+				},
+			},
+		},
+		Context: map[string]interface{}{
+			"customer_id":  subject,
+			"is_anonymous": isAnonymous,
+		},
+	}
+}
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	t.Run("Should allow a valid token from a TOKEN request", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		jwt_mock.On("VerifyJwtToken", mock.Anything, "token").
+			Return(entities.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "1"},
+				IsAnonymous:      false,
+			}, nil).
+			Once()
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_TOKEN,
+				MethodArn: methodArn,
+			},
+			AuthorizationToken: "Bearer token",
+		}
+
+		// Act
+		got, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, newAllowResponse("1", false), got)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should allow a valid token from a REQUEST request", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		jwt_mock.On("VerifyJwtToken", mock.Anything, "token").
+			Return(entities.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "2"},
+				IsAnonymous:      true,
+			}, nil).
+			Once()
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_REQUEST,
+				MethodArn: methodArn,
+				Headers:   map[string]string{"authorization": "Bearer token"},
+			},
+		}
+
+		// Act
+		got, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, newAllowResponse("2", true), got)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when the token is not valid", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		jwt_mock.On("VerifyJwtToken", mock.Anything, "token").
+			Return(entities.Claims{}, errors.New("token is expired")).
+			Once()
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_TOKEN,
+				MethodArn: methodArn,
+			},
+			AuthorizationToken: "Bearer token",
+		}
+
+		// Act
+		_, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should return the deadline error when the token verification runs out of time", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		jwt_mock.On("VerifyJwtToken", mock.Anything, "token").
+			Return(entities.Claims{}, context.DeadlineExceeded).
+			Once()
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_TOKEN,
+				MethodArn: methodArn,
+			},
+			AuthorizationToken: "Bearer token",
+		}
+
+		// Act
+		_, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrUnauthorized)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should return the deadline error when the revocation lookup is canceled by the deadline", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		jwt_mock.On("VerifyJwtToken", mock.Anything, "token").
+			Return(entities.Claims{}, &pq.Error{Code: "57014", Message: "canceling statement due to user request"}).
+			Once()
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_TOKEN,
+				MethodArn: methodArn,
+			},
+			AuthorizationToken: "Bearer token",
+		}
+
+		// Act
+		_, err := a.Authorize(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrUnauthorized)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when the token is missing", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      REQUEST_TYPE_REQUEST,
+				MethodArn: methodArn,
+			},
+		}
+
+		// Act
+		_, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		jwt_mock.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized for an unknown request type", func(t *testing.T) {
+		// Arrange
+		jwt_mock := token_interface_mock.NewMockToken(t)
+
+		a := NewAuthorizer(jwt_mock)
+
+		req := Request{
+			APIGatewayCustomAuthorizerRequestTypeRequest: events.APIGatewayCustomAuthorizerRequestTypeRequest{
+				Type:      "COGNITO",
+				MethodArn: methodArn,
+			},
+			AuthorizationToken: "Bearer token",
+		}
+
+		// Act
+		_, err := a.Authorize(context.Background(), req)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		jwt_mock.AssertExpectations(t)
+	})
+}
+
+func TestResourceFromMethodArn(t *testing.T) {
+	tests := []struct {
+		name      string
+		methodArn string
+		want      string
+	}{
+		{
+			name:      "Widen the method ARN to the whole stage",
+			methodArn: methodArn,
+			want:      "arn:aws:execute-api:us-east-1:123456789012:abcdef/prod/*/*",
+		},
+		{
+			name:      "Keep a method ARN without stage",
+			methodArn: "arn:aws:execute-api:us-east-1:123456789012:abcdef",
+			want:      "arn:aws:execute-api:us-east-1:123456789012:abcdef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceFromMethodArn(tt.methodArn); got != tt.want {
+				t.Errorf("resourceFromMethodArn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
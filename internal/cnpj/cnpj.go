@@ -0,0 +1,76 @@
+package cnpj
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+)
+
+var (
+	cnpjFirstDigitTable  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondDigitTable = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+const (
+	CNPJFormatPattern string = `([\dA-Z]{2})([\dA-Z]{3})([\dA-Z]{3})([\dA-Z]{4})([\d]{2})`
+)
+
+var (
+	ErrInvalidCNPJ = errors.New("invalid cnpj")
+)
+
+type CNPJ string
+
+func NewCNPJ(s string) CNPJ {
+	return CNPJ(Clean(s))
+}
+
+func (c *CNPJ) IsValid() bool {
+	return ValidateCNPJ(string(*c))
+}
+
+func (c *CNPJ) Validate() error {
+	if !c.IsValid() {
+		return ErrInvalidCNPJ
+	}
+
+	return nil
+}
+
+func (c *CNPJ) Clean() string {
+	return string(*c)
+}
+
+func (c *CNPJ) Format() string {
+	return c.String()
+}
+
+func (c *CNPJ) Type() int {
+	return entities.DOCUMENT_TYPE_CNPJ
+}
+
+func (c *CNPJ) String() string {
+	str := string(*c)
+
+	expr, err := regexp.Compile(CNPJFormatPattern)
+	if err != nil {
+		return str
+	}
+
+	if !c.IsValid() {
+		return str
+	}
+
+	return expr.ReplaceAllString(str, "$1.$2.$3/$4-$5")
+}
+
+func (c *CNPJ) Mask() string {
+	cnpj := string(*c)
+	if len(cnpj) < 4 {
+		return strings.Repeat("*", len(cnpj))
+	}
+
+	return cnpj[:2] + strings.Repeat("*", len(cnpj)-4) + cnpj[len(cnpj)-2:]
+}
@@ -0,0 +1,90 @@
+package cnpj
+
+import (
+	"testing"
+)
+
+func TestNewCNPJ(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantString     string
+		wantValidation bool
+	}{
+		{
+			name: "Create a new CNPJ with a valid value",
+			args: args{
+				s: "11.222.333/0001-81",
+			},
+			wantString:     "11.222.333/0001-81",
+			wantValidation: true,
+		},
+		{
+			name: "Create a new CNPJ with a valid alphanumeric value",
+			args: args{
+				s: "12.abc.345/01de-35",
+			},
+			wantString:     "12.ABC.345/01DE-35",
+			wantValidation: true,
+		},
+		{
+			name: "Create a new CNPJ with an invalid value",
+			args: args{
+				s: "11.222.333/0001-82",
+			},
+			wantString:     "11222333000182",
+			wantValidation: false,
+		},
+		{
+			name: "Create a new CNPJ made of a repeated 0",
+			args: args{
+				s: "00.000.000/0000-00",
+			},
+			wantString:     "00000000000000",
+			wantValidation: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCNPJ(tt.args.s)
+
+			if got.IsValid() != tt.wantValidation {
+				t.Errorf("IsValid() = %v, want %v", got, tt.wantValidation)
+				return
+			}
+
+			if got.String() != tt.wantString {
+				t.Errorf("String() = %v, want %v", got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestCNPJ_Mask(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CNPJ
+		want string
+	}{
+		{
+			name: "Mask a valid CNPJ",
+			c:    NewCNPJ("11.222.333/0001-81"),
+			want: "11**********81",
+		},
+		{
+			name: "Mask a valid alphanumeric CNPJ",
+			c:    NewCNPJ("12.ABC.345/01DE-35"),
+			want: "12**********35",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Mask(); got != tt.want {
+				t.Errorf("CNPJ.Mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
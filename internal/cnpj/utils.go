@@ -0,0 +1,51 @@
+package cnpj
+
+import (
+	"strings"
+)
+
+// valueOf follows the alphanumeric CNPJ rule, where every character is worth
+// its ASCII code minus 48, keeping the digits worth their own value
+func valueOf(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9', c >= 'A' && c <= 'Z':
+		return int(c) - 48, true
+	}
+
+	return 0, false
+}
+
+func sumDigit(s string, table []int) int {
+	if len(s) != len(table) {
+		return 0
+	}
+
+	sum := 0
+
+	for i, v := range table {
+		d, ok := valueOf(s[i])
+		if ok {
+			sum += v * d
+		}
+	}
+
+	return sum
+}
+
+func checkDigit(s string, table []int) int {
+	r := sumDigit(s, table) % 11
+
+	if r < 2 {
+		return 0
+	}
+
+	return 11 - r
+}
+
+func Clean(cnpj string) string {
+	charsToRemove := []string{"/", ".", "-", " "}
+	for _, char := range charsToRemove {
+		cnpj = strings.ReplaceAll(cnpj, char, "")
+	}
+	return strings.ToUpper(cnpj)
+}
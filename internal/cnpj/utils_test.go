@@ -0,0 +1,80 @@
+package cnpj
+
+import (
+	"testing"
+)
+
+func Test_valueOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      byte
+		want   int
+		wantOk bool
+	}{
+		{
+			name:   "Value of a digit",
+			c:      '7',
+			want:   7,
+			wantOk: true,
+		},
+		{
+			name:   "Value of a letter",
+			c:      'A',
+			want:   17,
+			wantOk: true,
+		},
+		{
+			name:   "Value of the last letter",
+			c:      'Z',
+			want:   42,
+			wantOk: true,
+		},
+		{
+			name:   "Value of a lowercase letter",
+			c:      'a',
+			want:   0,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := valueOf(tt.c)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("valueOf() = (%v, %v), want (%v, %v)", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestClean(t *testing.T) {
+	type args struct {
+		cnpj string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Clean a formatted CNPJ",
+			args: args{
+				cnpj: "11.222.333/0001-81",
+			},
+			want: "11222333000181",
+		},
+		{
+			name: "Clean and uppercase an alphanumeric CNPJ",
+			args: args{
+				cnpj: "12.abc.345/01de-35",
+			},
+			want: "12ABC34501DE35",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clean(tt.args.cnpj); got != tt.want {
+				t.Errorf("Clean() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
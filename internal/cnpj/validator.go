@@ -0,0 +1,45 @@
+package cnpj
+
+import (
+	"fmt"
+)
+
+// ValidateCNPJ accepts both the numeric CNPJ and the alphanumeric one issued
+// from 2026, where the first 12 characters may be letters and the check digits
+// are still numeric
+func ValidateCNPJ(cnpj string) bool {
+	if len(cnpj) != 14 {
+		return false
+	}
+
+	if isRepeatedCharacters(cnpj) {
+		return false
+	}
+
+	for i := 0; i < 12; i++ {
+		if _, ok := valueOf(cnpj[i]); !ok {
+			return false
+		}
+	}
+
+	firstPart := cnpj[0:12]
+	d1 := checkDigit(firstPart, cnpjFirstDigitTable)
+
+	secondPart := fmt.Sprintf("%s%d", firstPart, d1)
+	d2 := checkDigit(secondPart, cnpjSecondDigitTable)
+
+	finalPart := fmt.Sprintf("%s%d", secondPart, d2)
+	return finalPart == cnpj
+}
+
+// isRepeatedCharacters finds the sequences like 00.000.000/0000-00, their check
+// digits are right but Receita Federal never issues them
+func isRepeatedCharacters(cnpj string) bool {
+	for i := 1; i < len(cnpj); i++ {
+		if cnpj[i] != cnpj[0] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+package cnpj
+
+import "testing"
+
+func TestValidateCNPJ(t *testing.T) {
+	type args struct {
+		cnpj string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Validate a valid CNPJ",
+			args: args{
+				cnpj: "11222333000181",
+			},
+			want: true,
+		},
+		{
+			name: "Validate a valid alphanumeric CNPJ",
+			args: args{
+				cnpj: "12ABC34501DE35",
+			},
+			want: true,
+		},
+		{
+			name: "Validate an invalid CNPJ",
+			args: args{
+				cnpj: "11222333000182",
+			},
+			want: false,
+		},
+		{
+			name: "Validate an invalid alphanumeric CNPJ",
+			args: args{
+				cnpj: "12ABC34501DF35",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CNPJ with letters in the check digits",
+			args: args{
+				cnpj: "12ABC34501DE3A",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CNPJ with lowercase letters",
+			args: args{
+				cnpj: "12abc34501de35",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CNPJ made of a repeated 0",
+			args: args{
+				cnpj: "00000000000000",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CNPJ made of a repeated 1",
+			args: args{
+				cnpj: "11111111111111",
+			},
+			want: false,
+		},
+		{
+			name: "Validate an invalid CNPJ with wrong length",
+			args: args{
+				cnpj: "123",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateCNPJ(tt.args.cnpj); got != tt.want {
+				t.Errorf("ValidateCNPJ() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
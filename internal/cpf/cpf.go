@@ -3,6 +3,8 @@ package cpf
 import (
 	"regexp"
 	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 )
 
 var (
@@ -17,11 +19,32 @@ const (
 type CPF string
 
 func NewCPF(s string) CPF {
+	if cpf, err := Parse(s); err == nil {
+		return cpf
+	}
+
 	return CPF(Clean(s))
 }
 
 func (c *CPF) IsValid() bool {
-	return ValidateCPF(string(*c))
+	return c.Validate() == nil
+}
+
+func (c *CPF) Validate() error {
+	_, err := Parse(string(*c))
+	return err
+}
+
+func (c *CPF) Clean() string {
+	return string(*c)
+}
+
+func (c *CPF) Format() string {
+	return c.String()
+}
+
+func (c *CPF) Type() int {
+	return entities.DOCUMENT_TYPE_CPF
 }
 
 func (c *CPF) String() string {
@@ -41,6 +64,9 @@ func (c *CPF) String() string {
 
 func (c *CPF) Mask() string {
 	cpf := string(*c)
+	if len(cpf) < 5 {
+		return strings.Repeat("*", len(cpf))
+	}
 
 	return strings.ReplaceAll(cpf, cpf[3:(len(cpf)-2)], strings.Repeat("*", len(cpf)-5))
 }
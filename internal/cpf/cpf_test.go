@@ -58,6 +58,11 @@ func TestCPF_Mask(t *testing.T) {
 			c:    NewCPF("231.654.140-25"),
 			want: "231******25",
 		},
+		{
+			name: "Mask a short value entirely",
+			c:    NewCPF("12"),
+			want: "**",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,43 @@
+package cpf
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	denylistMu sync.RWMutex
+	denylist   = map[string]struct{}{}
+)
+
+// SetDenylist replaces the CPFs refused even when their check digits are right,
+// like the fake ones spread by demos and test suites
+func SetDenylist(cpfs []string) {
+	list := make(map[string]struct{}, len(cpfs))
+
+	for _, cpf := range cpfs {
+		if cpf = Clean(strings.TrimSpace(cpf)); cpf != "" {
+			list[cpf] = struct{}{}
+		}
+	}
+
+	denylistMu.Lock()
+	defer denylistMu.Unlock()
+
+	denylist = list
+}
+
+// LoadDenylistFromEnv reads the denylist from CPF_DENYLIST, a comma separated
+// list of CPFs with or without formatting
+func LoadDenylistFromEnv() {
+	SetDenylist(strings.Split(os.Getenv("CPF_DENYLIST"), ","))
+}
+
+func IsDenied(cpf string) bool {
+	denylistMu.RLock()
+	defer denylistMu.RUnlock()
+
+	_, ok := denylist[Clean(cpf)]
+	return ok
+}
@@ -0,0 +1,62 @@
+package cpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDenylist(t *testing.T) {
+	t.Cleanup(func() {
+		SetDenylist(nil)
+	})
+
+	t.Run("Should accept a valid CPF when the denylist is empty", func(t *testing.T) {
+		// Arrange
+		SetDenylist(nil)
+		cpf := NewCPF("231.654.140-25")
+
+		// Act
+		got := cpf.IsValid()
+
+		// Assert
+		assert.True(t, got)
+	})
+
+	t.Run("Should reject a valid CPF present in the denylist", func(t *testing.T) {
+		// Arrange
+		SetDenylist([]string{" 231.654.140-25", "16115279020"})
+		cpf := NewCPF("23165414025")
+
+		// Act
+		got := cpf.IsValid()
+
+		// Assert
+		assert.False(t, got)
+		assert.True(t, IsDenied("161.152.790-20"))
+	})
+
+	t.Run("Should load the denylist from the environment", func(t *testing.T) {
+		// Arrange
+		t.Setenv("CPF_DENYLIST", "231.654.140-25, 161.152.790-20")
+
+		// Act
+		LoadDenylistFromEnv()
+
+		// Assert
+		assert.True(t, IsDenied("23165414025"))
+		assert.True(t, IsDenied("16115279020"))
+		assert.False(t, IsDenied("21848631065"))
+	})
+
+	t.Run("Should clear the denylist when the environment is empty", func(t *testing.T) {
+		// Arrange
+		t.Setenv("CPF_DENYLIST", "")
+
+		// Act
+		LoadDenylistFromEnv()
+
+		// Assert
+		assert.False(t, IsDenied("23165414025"))
+	})
+}
@@ -0,0 +1,66 @@
+package cpf
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidCharacters = errors.New("cpf has invalid characters")
+	ErrInvalidLength     = errors.New("cpf must have 11 digits")
+	ErrInvalidCheckDigit = errors.New("cpf check digits do not match")
+	ErrRepeatedDigits    = errors.New("cpf must not be a repeated digit sequence")
+	ErrDenied            = errors.New("cpf is not accepted")
+)
+
+// Parse normalizes a CPF typed by a customer, accepting the usual separators,
+// any whitespace and the digits of every Unicode script, and tells which rule
+// the value breaks when it is not a valid CPF
+func Parse(s string) (CPF, error) {
+	var builder strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case unicode.IsDigit(r):
+			builder.WriteByte(byte('0' + digitValue(r)))
+		case r == '.' || r == '-' || r == '/' || unicode.IsSpace(r):
+			continue
+		default:
+			return "", ErrInvalidCharacters
+		}
+	}
+
+	cpf := builder.String()
+
+	if len(cpf) != 11 {
+		return "", ErrInvalidLength
+	}
+
+	if isRepeatedDigits(cpf) {
+		return "", ErrRepeatedDigits
+	}
+
+	if !ValidateCPF(cpf) {
+		return "", ErrInvalidCheckDigit
+	}
+
+	if IsDenied(cpf) {
+		return "", ErrDenied
+	}
+
+	return CPF(cpf), nil
+}
+
+// digitValue finds the value of a decimal digit of any script, Unicode keeps
+// them in contiguous runs starting at zero
+func digitValue(r rune) int {
+	zero := r
+	for unicode.IsDigit(zero - 1) {
+		zero--
+	}
+
+	return int(r-zero) % 10
+}
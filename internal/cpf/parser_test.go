@@ -0,0 +1,91 @@
+package cpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    CPF
+		wantErr error
+	}{
+		{
+			name:  "Parse a formatted CPF",
+			value: "218.486.310-65",
+			want:  "21848631065",
+		},
+		{
+			name:  "Parse a CPF with stray whitespace",
+			value: " \t218 486 310 65\n",
+			want:  "21848631065",
+		},
+		{
+			name:  "Parse a CPF typed with full-width digits",
+			value: "２１８.４８６.３１０-６５",
+			want:  "21848631065",
+		},
+		{
+			name:  "Parse a CPF typed with arabic-indic digits",
+			value: "٢١٨٤٨٦٣١٠٦٥",
+			want:  "21848631065",
+		},
+		{
+			name:    "Do not parse a CPF with letters",
+			value:   "548a644b62097",
+			wantErr: ErrInvalidCharacters,
+		},
+		{
+			name:    "Do not parse a CPF with the wrong length",
+			value:   "218.486.310-6",
+			wantErr: ErrInvalidLength,
+		},
+		{
+			name:    "Do not parse an empty CPF",
+			value:   "",
+			wantErr: ErrInvalidLength,
+		},
+		{
+			name:    "Do not parse a CPF with a wrong check digit",
+			value:   "218.486.310-66",
+			wantErr: ErrInvalidCheckDigit,
+		},
+		{
+			name:    "Do not parse a repeated digit sequence",
+			value:   "111.111.111-11",
+			wantErr: ErrRepeatedDigits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := Parse(tt.value)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Denied(t *testing.T) {
+	// Arrange
+	SetDenylist([]string{"218.486.310-65"})
+	t.Cleanup(func() {
+		SetDenylist(nil)
+	})
+
+	// Act
+	_, err := Parse("21848631065")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDenied)
+}
@@ -0,0 +1,43 @@
+package cpf
+
+// Region is the fiscal region of Receita Federal where a CPF was issued, it is
+// encoded by the ninth digit of the CPF
+type Region struct {
+	Code   int
+	States []string
+}
+
+var regionStates = map[int][]string{
+	0: {"RS"},
+	1: {"DF", "GO", "MS", "MT", "TO"},
+	2: {"AC", "AM", "AP", "PA", "RO", "RR"},
+	3: {"CE", "MA", "PI"},
+	4: {"AL", "PB", "PE", "RN"},
+	5: {"BA", "SE"},
+	6: {"MG"},
+	7: {"ES", "RJ"},
+	8: {"SP"},
+	9: {"PR", "SC"},
+}
+
+func NewRegion(code int) (Region, bool) {
+	states, ok := regionStates[code]
+	if !ok {
+		return Region{}, false
+	}
+
+	return Region{
+		Code:   code,
+		States: states,
+	}, true
+}
+
+// Region returns the fiscal region of a valid CPF, formatted or not
+func (c *CPF) Region() (Region, bool) {
+	cpf, err := Parse(string(*c))
+	if err != nil {
+		return Region{}, false
+	}
+
+	return NewRegion(int(string(cpf)[8] - '0'))
+}
@@ -0,0 +1,66 @@
+package cpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPF_Region(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      CPF
+		want   Region
+		wantOk bool
+	}{
+		{
+			name:   "Return the region of a CPF issued in SP",
+			c:      NewCPF("548.644.628-44"),
+			want:   Region{Code: 8, States: []string{"SP"}},
+			wantOk: true,
+		},
+		{
+			name:   "Return the region of a CPF issued in RJ or ES",
+			c:      NewCPF("231.654.147-00"),
+			want:   Region{Code: 7, States: []string{"ES", "RJ"}},
+			wantOk: true,
+		},
+		{
+			name:   "Return the region of a CPF issued in RS",
+			c:      NewCPF("218.486.310-65"),
+			want:   Region{Code: 0, States: []string{"RS"}},
+			wantOk: true,
+		},
+		{
+			name:   "Return the region of a formatted CPF",
+			c:      CPF("548.644.620-97"),
+			want:   Region{Code: 0, States: []string{"RS"}},
+			wantOk: true,
+		},
+		{
+			name:   "Do not return a region for an invalid CPF",
+			c:      NewCPF("123"),
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, ok := tt.c.Region()
+
+			// Assert
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewRegion(t *testing.T) {
+	t.Run("Should not create an unknown region", func(t *testing.T) {
+		// Act
+		_, ok := NewRegion(10)
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
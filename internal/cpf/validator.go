@@ -10,6 +10,10 @@ func ValidateCPF(cpf string) bool {
 		return false
 	}
 
+	if isRepeatedDigits(cpf) {
+		return false
+	}
+
 	firstPart := cpf[0:9]
 	sum := sumDigit(firstPart, cpfFirstDigitTable)
 
@@ -34,3 +38,15 @@ func ValidateCPF(cpf string) bool {
 	finalPart := fmt.Sprintf("%s%d%d", firstPart, d1, d2)
 	return finalPart == cpf
 }
+
+// isRepeatedDigits finds the sequences like 111.111.111-11, their check digits
+// are right but Receita Federal never issues them
+func isRepeatedDigits(cpf string) bool {
+	for i := 1; i < len(cpf); i++ {
+		if cpf[i] != cpf[0] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -32,6 +32,34 @@ func TestValidateCPF(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Validate a CPF made of a repeated 0",
+			args: args{
+				cpf: "00000000000",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CPF made of a repeated 1",
+			args: args{
+				cpf: "11111111111",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CPF made of a repeated 5",
+			args: args{
+				cpf: "55555555555",
+			},
+			want: false,
+		},
+		{
+			name: "Validate a CPF made of a repeated 9",
+			args: args{
+				cpf: "99999999999",
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
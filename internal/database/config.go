@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// a Lambda instance serves one request at a time, a couple of connections
+	// is enough and keeps the instances from exhausting the database
+	DEFAULT_MAX_OPEN_CONNS     = 2
+	DEFAULT_MAX_IDLE_CONNS     = 2
+	DEFAULT_CONN_MAX_LIFETIME  = time.Minute * 15
+	DEFAULT_CONN_MAX_IDLE_TIME = time.Minute * 5
+	DEFAULT_CREDENTIALS_TTL    = time.Minute * 5
+	DEFAULT_CREDENTIALS_RETRY  = time.Second * 30
+	DEFAULT_PING_TIMEOUT       = time.Second * 5
+)
+
+type Config struct {
+	URL             string
+	SecretId        string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	CredentialsTTL  time.Duration
+	// CredentialsRetry is the wait after a failed refresh, so an unavailable
+	// secrets extension does not slow down every invocation
+	CredentialsRetry time.Duration
+	PingTimeout      time.Duration
+}
+
+// NewConfigFromEnv loads the connection string from DB_URL, or from the secret
+// named by DB_SECRET_ID when it is informed, and the pool settings from the
+// DB_* variables
+func NewConfigFromEnv() (Config, error) {
+	config := Config{
+		URL:              os.Getenv("DB_URL"),
+		SecretId:         os.Getenv("DB_SECRET_ID"),
+		MaxOpenConns:     DEFAULT_MAX_OPEN_CONNS,
+		MaxIdleConns:     DEFAULT_MAX_IDLE_CONNS,
+		ConnMaxLifetime:  DEFAULT_CONN_MAX_LIFETIME,
+		ConnMaxIdleTime:  DEFAULT_CONN_MAX_IDLE_TIME,
+		CredentialsTTL:   DEFAULT_CREDENTIALS_TTL,
+		CredentialsRetry: DEFAULT_CREDENTIALS_RETRY,
+		PingTimeout:      DEFAULT_PING_TIMEOUT,
+	}
+
+	var err error
+	if config.MaxOpenConns, err = intFromEnv("DB_MAX_OPEN_CONNS", config.MaxOpenConns); err != nil {
+		return Config{}, err
+	}
+
+	if config.MaxIdleConns, err = intFromEnv("DB_MAX_IDLE_CONNS", config.MaxIdleConns); err != nil {
+		return Config{}, err
+	}
+
+	if config.MaxIdleConns > config.MaxOpenConns {
+		config.MaxIdleConns = config.MaxOpenConns
+	}
+
+	if config.ConnMaxLifetime, err = durationFromEnv("DB_CONN_MAX_LIFETIME", config.ConnMaxLifetime); err != nil {
+		return Config{}, err
+	}
+
+	if config.ConnMaxIdleTime, err = durationFromEnv("DB_CONN_MAX_IDLE_TIME", config.ConnMaxIdleTime); err != nil {
+		return Config{}, err
+	}
+
+	if config.CredentialsTTL, err = durationFromEnv("DB_CREDENTIALS_TTL", config.CredentialsTTL); err != nil {
+		return Config{}, err
+	}
+
+	if config.CredentialsRetry, err = durationFromEnv("DB_CREDENTIALS_RETRY", config.CredentialsRetry); err != nil {
+		return Config{}, err
+	}
+
+	if config.PingTimeout, err = durationFromEnv("DB_PING_TIMEOUT", config.PingTimeout); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+func intFromEnv(name string, fallback int) (int, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+
+	return value, nil
+}
+
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+
+	return value, nil
+}
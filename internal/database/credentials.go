@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/database/interfaces"
+)
+
+const (
+	SECRETS_EXTENSION_PORT         = "2773"
+	SECRETS_EXTENSION_TOKEN_HEADER = "X-Aws-Parameters-Secrets-Token"
+)
+
+var (
+	ErrMissingConnStr = errors.New("missing DB_URL or DB_SECRET_ID")
+	ErrEmptySecret    = errors.New("the database secret is empty")
+)
+
+func NewCredentialsFromConfig(config Config) (interfaces.Credentials, error) {
+	if config.SecretId != "" {
+		return NewSecretsExtensionCredentials(config.SecretId), nil
+	}
+
+	if config.URL != "" {
+		return NewEnvCredentials(config.URL), nil
+	}
+
+	return nil, ErrMissingConnStr
+}
+
+type EnvCredentials struct {
+	connStr string
+}
+
+func NewEnvCredentials(connStr string) *EnvCredentials {
+	return &EnvCredentials{
+		connStr: connStr,
+	}
+}
+
+func (c *EnvCredentials) GetConnStr(ctx context.Context) (string, error) {
+	return c.connStr, nil
+}
+
+// SecretsExtensionCredentials reads the connection string through the AWS
+// Parameters and Secrets Lambda extension, which caches the secret locally so
+// it can be read on every refresh and still follow a rotation
+type SecretsExtensionCredentials struct {
+	secretId string
+	endpoint string
+	client   *http.Client
+}
+
+func NewSecretsExtensionCredentials(secretId string) *SecretsExtensionCredentials {
+	port := os.Getenv("PARAMETERS_SECRETS_EXTENSION_HTTP_PORT")
+	if port == "" {
+		port = SECRETS_EXTENSION_PORT
+	}
+
+	return NewSecretsExtensionCredentialsWithEndpoint(secretId, "http://"+net.JoinHostPort("localhost", port), http.DefaultClient)
+}
+
+func NewSecretsExtensionCredentialsWithEndpoint(secretId string, endpoint string, client *http.Client) *SecretsExtensionCredentials {
+	return &SecretsExtensionCredentials{
+		secretId: secretId,
+		endpoint: endpoint,
+		client:   client,
+	}
+}
+
+type secretValue struct {
+	SecretString string `json:"SecretString"`
+}
+
+func (c *SecretsExtensionCredentials) GetConnStr(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/secretsmanager/get?secretId="+url.QueryEscape(c.secretId), nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set(SECRETS_EXTENSION_TOKEN_HEADER, os.Getenv("AWS_SESSION_TOKEN"))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error reading the database secret: status %d", resp.StatusCode)
+	}
+
+	var secret secretValue
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", err
+	}
+
+	return parseSecret(secret.SecretString)
+}
+
+type rdsSecret struct {
+	Username string          `json:"username"`
+	Password string          `json:"password"`
+	Host     string          `json:"host"`
+	Port     json.RawMessage `json:"port"`
+	DbName   string          `json:"dbname"`
+}
+
+// parseSecret accepts the plain connection string and the JSON document kept
+// by the RDS managed rotation
+func parseSecret(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", ErrEmptySecret
+	}
+
+	if !strings.HasPrefix(value, "{") {
+		return value, nil
+	}
+
+	var secret rdsSecret
+	if err := json.Unmarshal([]byte(value), &secret); err != nil {
+		return "", err
+	}
+
+	host := secret.Host
+	if port := strings.Trim(string(secret.Port), `"`); port != "" {
+		if _, err := strconv.Atoi(port); err != nil {
+			return "", fmt.Errorf("invalid database secret port %q", port)
+		}
+		host = net.JoinHostPort(secret.Host, port)
+	}
+
+	connStr := url.URL{
+		Scheme: engine,
+		User:   url.UserPassword(secret.Username, secret.Password),
+		Host:   host,
+		Path:   secret.DbName,
+	}
+
+	return connStr.String(), nil
+}
@@ -1,22 +1,56 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"os"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	database_interface "github.com/jfelipearaujo-org/lambda-register/internal/database/interfaces"
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 	"github.com/jfelipearaujo-org/lambda-register/internal/providers/interfaces"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCredentialsRefused = errors.New("the database refused the current credentials")
 )
 
 const (
 	engine = "postgres"
 
-	DOCUMENT_TYPE_CPF = 1
+	UNIQUE_VIOLATION      pq.ErrorCode  = "23505"
+	INVALID_AUTHORIZATION pq.ErrorClass = "28"
+
+	// DOCUMENT_INDEX is the unique index over the customers documents, created
+	// by the 0006 migration
+	DOCUMENT_INDEX = "idx_customers_document_id"
+
+	DEFAULT_PAGE_SIZE = 20
+	MAX_PAGE_SIZE     = 100
+
+	userColumns = "c.id, COALESCE(c.document_id, ''), c.document_type, COALESCE(c.password, ''), c.is_anonymous, c.fiscal_region, c.created_at, c.updated_at"
+
+	// the listing never reads the password hash
+	userListColumns = "c.id, COALESCE(c.document_id, ''), c.document_type, c.is_anonymous, c.fiscal_region, c.created_at, c.updated_at"
 )
 
 type Database struct {
-	conn         *sql.DB
+	mu          sync.RWMutex
+	conn        *sql.DB
+	connStr     string
+	refreshedAt time.Time
+	failedAt    time.Time
+	authFailed  bool
+
+	refreshMu   sync.Mutex
+	config      Config
+	credentials database_interface.Credentials
+
 	timeProvider interfaces.TimeProvider
 }
 
@@ -27,39 +61,354 @@ func NewDatabase(db *sql.DB, timeProvider interfaces.TimeProvider) *Database {
 	}
 }
 
-func NewDatabaseFromConnStr(timeProvider interfaces.TimeProvider) *Database {
-	db, err := sql.Open(engine, os.Getenv("DB_URL"))
+// NewDatabaseFromConfig opens the pool with the current credentials and checks
+// the database is reachable, it is meant to run once per Lambda instance
+func NewDatabaseFromConfig(ctx context.Context, config Config, credentials database_interface.Credentials, timeProvider interfaces.TimeProvider) (*Database, error) {
+	db := &Database{
+		config:       config,
+		credentials:  credentials,
+		timeProvider: timeProvider,
+	}
+
+	if err := db.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func NewConnFromEnv(ctx context.Context) (*sql.DB, error) {
+	config, err := NewConfigFromEnv()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return &Database{
-		conn:         db,
-		timeProvider: timeProvider,
+	credentials, err := NewCredentialsFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	connStr, err := credentials.GetConnStr(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	return Open(ctx, config, connStr)
+}
+
+// Open creates a pool tuned by the config and pings it, so a wrong connection
+// string or an unreachable database fails here instead of on the first query
+func Open(ctx context.Context, config Config, connStr string) (*sql.DB, error) {
+	return open(ctx, config, connStr, nil)
 }
 
-func (db *Database) CheckIfCPFIsInUse(cpf string) (bool, error) {
-	statement, err := db.conn.Query("SELECT COUNT(c.id) As count FROM customers c WHERE c.document_id = $1;", cpf)
+func open(ctx context.Context, config Config, connStr string, onAuthFailure func()) (*sql.DB, error) {
+	connector, err := pq.NewConnector(connStr)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	var count int
-	for statement.Next() {
-		if err := statement.Scan(&count); err != nil {
-			return false, err
+	conn := sql.OpenDB(authConnector{
+		Connector:     connector,
+		onAuthFailure: onAuthFailure,
+	})
+
+	conn.SetMaxOpenConns(config.MaxOpenConns)
+	conn.SetMaxIdleConns(config.MaxIdleConns)
+	conn.SetConnMaxLifetime(config.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+
+	if config.PingTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.PingTimeout)
+		defer cancel()
+	}
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// authConnector reports the new connections refused by the database, which is
+// how a rotation of the credentials shows up on a pool opened before it
+type authConnector struct {
+	driver.Connector
+	onAuthFailure func()
+}
+
+func (c authConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if c.onAuthFailure != nil && isAuthFailure(err) {
+		c.onAuthFailure()
+	}
+
+	return conn, err
+}
+
+func isAuthFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Class() == INVALID_AUTHORIZATION
+}
+
+func (db *Database) pool() *sql.DB {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.conn
+}
+
+func (db *Database) Ping(ctx context.Context) error {
+	return db.pool().PingContext(ctx)
+}
+
+func (db *Database) Close() error {
+	return db.pool().Close()
+}
+
+func (db *Database) markAuthFailure() {
+	db.mu.Lock()
+	db.authFailed = true
+	db.mu.Unlock()
+}
+
+// Refresh reads the credentials again and, when they changed, replaces the
+// pool by one opened with the new credentials. The current pool is kept if the
+// new one can not be opened
+func (db *Database) Refresh(ctx context.Context) error {
+	if db.credentials == nil {
+		return nil
+	}
+
+	db.refreshMu.Lock()
+	defer db.refreshMu.Unlock()
+
+	connStr, err := db.credentials.GetConnStr(ctx)
+	if err != nil {
+		db.refreshFailed()
+		return err
+	}
+
+	db.mu.RLock()
+	unchanged := db.conn != nil && db.connStr == connStr
+	authFailed := db.authFailed
+	db.mu.RUnlock()
+
+	if unchanged && authFailed {
+		// the secret still holds the credentials the database refused, the
+		// rotation did not reach it yet
+		db.refreshFailed()
+		return ErrCredentialsRefused
+	}
+
+	if unchanged {
+		db.mu.Lock()
+		db.refreshedAt = db.timeProvider.GetTime()
+		db.mu.Unlock()
+		return nil
+	}
+
+	conn, err := open(ctx, db.config, connStr, db.markAuthFailure)
+	if err != nil {
+		db.refreshFailed()
+		return err
+	}
+
+	db.mu.Lock()
+	previous := db.conn
+	db.conn = conn
+	db.connStr = connStr
+	db.refreshedAt = db.timeProvider.GetTime()
+	db.failedAt = time.Time{}
+	db.authFailed = false
+	db.mu.Unlock()
+
+	if previous != nil {
+		return previous.Close()
+	}
+
+	return nil
+}
+
+func (db *Database) refreshFailed() {
+	now := db.timeProvider.GetTime()
+
+	db.mu.Lock()
+	db.failedAt = now
+	db.mu.Unlock()
+}
+
+// RefreshIfStale refreshes the credentials once they are older than the
+// configured TTL, or right away when the database refused them, so a warm
+// instance follows a rotation of the secret. A failed refresh is only tried
+// again after the retry interval
+func (db *Database) RefreshIfStale(ctx context.Context) error {
+	if db.credentials == nil {
+		return nil
+	}
+
+	now := db.timeProvider.GetTime()
+
+	db.mu.RLock()
+	refreshedAt := db.refreshedAt
+	failedAt := db.failedAt
+	authFailed := db.authFailed
+	db.mu.RUnlock()
+
+	if !authFailed && now.Sub(refreshedAt) < db.config.CredentialsTTL {
+		return nil
+	}
+
+	if !failedAt.IsZero() && now.Sub(failedAt) < db.config.CredentialsRetry {
+		return nil
+	}
+
+	return db.Refresh(ctx)
+}
+
+func (db *Database) FindByID(ctx context.Context, id string) (entities.User, error) {
+	row := db.pool().QueryRowContext(ctx, "SELECT "+userColumns+" FROM customers c WHERE c.id = $1;", id)
+
+	return scanUser(row)
+}
+
+func (db *Database) FindByDocument(ctx context.Context, documentType int, documentId string) (entities.User, error) {
+	row := db.pool().QueryRowContext(ctx, "SELECT "+userColumns+" FROM customers c WHERE c.document_type = $1 AND c.document_id = $2 AND c.is_anonymous = false;", documentType, documentId)
+
+	return scanUser(row)
+}
+
+// ListUsers returns a page of users ordered by creation, without their password
+// hashes. One extra row is read to tell whether there is a next page
+func (db *Database) ListUsers(ctx context.Context, filter entities.UserFilter) (entities.UserPage, error) {
+	if filter.Offset < 0 || filter.Limit < 0 {
+		return entities.UserPage{}, entities.ErrInvalidUserFilter
+	}
+
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return entities.UserPage{}, entities.ErrInvalidUserFilter
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DEFAULT_PAGE_SIZE
+	}
+	if limit > MAX_PAGE_SIZE {
+		limit = MAX_PAGE_SIZE
+	}
+
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.IsAnonymous != nil {
+		where("c.is_anonymous = $%d", *filter.IsAnonymous)
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		where("c.created_at >= $%d", filter.CreatedFrom)
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		where("c.created_at < $%d", filter.CreatedTo)
+	}
+
+	query := "SELECT " + userListColumns + " FROM customers c"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, limit+1, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY c.created_at, c.id LIMIT $%d OFFSET $%d;", len(args)-1, len(args))
+
+	rows, err := db.pool().QueryContext(ctx, query, args...)
+	if err != nil {
+		return entities.UserPage{}, err
+	}
+	defer rows.Close()
+
+	page := entities.UserPage{
+		Users: []entities.User{},
+	}
+
+	for rows.Next() {
+		user, err := scanListedUser(rows)
+		if err != nil {
+			return entities.UserPage{}, err
+		}
+		page.Users = append(page.Users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entities.UserPage{}, err
+	}
+
+	if len(page.Users) > limit {
+		page.Users = page.Users[:limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser reads a row of the userColumns
+func scanUser(row scanner) (entities.User, error) {
+	return scanUserColumns(row, true)
+}
+
+// scanListedUser reads a row of the userListColumns, without the password hash
+func scanListedUser(row scanner) (entities.User, error) {
+	return scanUserColumns(row, false)
+}
+
+func scanUserColumns(row scanner, withPassword bool) (entities.User, error) {
+	var user entities.User
+	var fiscalRegion sql.NullInt16
+	var createdAt, updatedAt sql.NullTime
+
+	dest := []any{&user.Id, &user.DocumentId, &user.DocumentType}
+	if withPassword {
+		dest = append(dest, &user.Password)
+	}
+	dest = append(dest, &user.IsAnonymous, &fiscalRegion, &createdAt, &updatedAt)
+
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.User{}, entities.ErrUserNotFound
 		}
+		return entities.User{}, err
+	}
+
+	if fiscalRegion.Valid {
+		region := int(fiscalRegion.Int16)
+		user.FiscalRegion = &region
+	}
+
+	if createdAt.Valid {
+		user.CreatedAt = createdAt.Time
+	}
+
+	if updatedAt.Valid {
+		user.UpdatedAt = updatedAt.Time
 	}
 
-	return count > 0, nil
+	return user, nil
 }
 
-func (db *Database) PersistUser(user entities.User) error {
+func (db *Database) PersistUser(ctx context.Context, user entities.User) error {
 	if user.IsAnonymous {
-		_, err := db.conn.Exec("INSERT INTO customers (id, document_type, is_anonymous, created_at, updated_at) VALUES ($1, $2, $3, $4, $5);",
+		_, err := db.pool().ExecContext(ctx, "INSERT INTO customers (id, document_type, is_anonymous, created_at, updated_at) VALUES ($1, $2, $3, $4, $5);",
 			user.Id,
-			DOCUMENT_TYPE_CPF,
+			user.DocumentType,
 			true,
 			db.timeProvider.GetTime(),
 			db.timeProvider.GetTime())
@@ -68,19 +417,221 @@ func (db *Database) PersistUser(user entities.User) error {
 			return err
 		}
 	} else {
-		_, err := db.conn.Exec("INSERT INTO customers (id, document_id, document_type, is_anonymous, password, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7);",
+		_, err := db.pool().ExecContext(ctx, "INSERT INTO customers (id, document_id, document_type, is_anonymous, password, fiscal_region, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);",
 			user.Id,
 			user.DocumentId,
-			DOCUMENT_TYPE_CPF,
+			user.DocumentType,
 			false,
 			user.Password,
+			user.FiscalRegion,
 			db.timeProvider.GetTime(),
 			db.timeProvider.GetTime())
 
 		if err != nil {
-			return err
+			return documentConflict(err)
 		}
 	}
 
 	return nil
 }
+
+// documentConflict maps the violation of the unique index of the customers
+// documents, keyed by type and number, to ErrDocumentAlreadyInUse, it is the
+// only guard against two concurrent registrations of the same document
+func documentConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == UNIQUE_VIOLATION && pqErr.Constraint == DOCUMENT_INDEX {
+		return errors.Join(entities.ErrDocumentAlreadyInUse, err)
+	}
+
+	return err
+}
+
+func (db *Database) UpgradeUser(ctx context.Context, user entities.User) error {
+	result, err := db.pool().ExecContext(ctx, "UPDATE customers SET document_id = $1, document_type = $2, is_anonymous = $3, password = $4, fiscal_region = $5, updated_at = $6 WHERE id = $7 AND is_anonymous = true;",
+		user.DocumentId,
+		user.DocumentType,
+		false,
+		user.Password,
+		user.FiscalRegion,
+		db.timeProvider.GetTime(),
+		user.Id)
+	if err != nil {
+		return documentConflict(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return entities.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// RehashPassword locks the user row and stores the hash returned by rehash for
+// the current one in the same transaction, so the hash that is replaced is the
+// one rehash saw. An empty hash leaves the row untouched
+func (db *Database) RehashPassword(ctx context.Context, userId string, rehash func(currentHash string) (string, error)) error {
+	tx, err := db.pool().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var currentHash string
+	row := tx.QueryRowContext(ctx, "SELECT COALESCE(c.password, '') FROM customers c WHERE c.id = $1 AND c.is_anonymous = false FOR UPDATE;", userId)
+	if err := row.Scan(&currentHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.ErrUserNotFound
+		}
+		return err
+	}
+
+	hashedPassword, err := rehash(currentHash)
+	if err != nil {
+		return err
+	}
+
+	if hashedPassword == "" {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE customers SET password = $1, updated_at = $2 WHERE id = $3;",
+		hashedPassword,
+		db.timeProvider.GetTime(),
+		userId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *Database) PersistRefreshToken(ctx context.Context, token entities.RefreshToken) error {
+	_, err := db.pool().ExecContext(ctx, "INSERT INTO refresh_tokens (id, family_id, customer_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6);",
+		token.Id,
+		token.FamilyId,
+		token.UserId,
+		token.TokenHash,
+		token.ExpiresAt,
+		db.timeProvider.GetTime())
+
+	return err
+}
+
+func (db *Database) GetRefreshToken(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+	row := db.pool().QueryRowContext(ctx, "SELECT t.id, t.family_id, t.customer_id, t.token_hash, t.expires_at, t.used_at, t.revoked_at FROM refresh_tokens t WHERE t.token_hash = $1;", tokenHash)
+
+	var token entities.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+	if err := row.Scan(&token.Id, &token.FamilyId, &token.UserId, &token.TokenHash, &token.ExpiresAt, &usedAt, &revokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.RefreshToken{}, entities.ErrRefreshTokenNotFound
+		}
+		return entities.RefreshToken{}, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks the current token as used and persists the next one
+// atomically, failing with ErrRefreshTokenReused if the current token was
+// already consumed by a concurrent request
+func (db *Database) RotateRefreshToken(ctx context.Context, current entities.RefreshToken, next entities.RefreshToken) error {
+	tx, err := db.pool().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := db.timeProvider.GetTime()
+
+	result, err := tx.ExecContext(ctx, "UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL AND revoked_at IS NULL;",
+		now,
+		current.Id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return entities.ErrRefreshTokenReused
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO refresh_tokens (id, family_id, customer_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6);",
+		next.Id,
+		next.FamilyId,
+		next.UserId,
+		next.TokenHash,
+		next.ExpiresAt,
+		now)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *Database) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	_, err := db.pool().ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL;",
+		db.timeProvider.GetTime(),
+		familyId)
+
+	return err
+}
+
+// RevokeToken denies the access token until it expires, entries past their
+// expiration are purged since the token would be rejected anyway
+func (db *Database) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	now := db.timeProvider.GetTime()
+
+	if _, err := db.pool().ExecContext(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= $1;", now); err != nil {
+		return err
+	}
+
+	_, err := db.pool().ExecContext(ctx, "INSERT INTO revoked_tokens (jti, expires_at, revoked_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING;",
+		jti,
+		expiresAt,
+		now)
+
+	return err
+}
+
+func (db *Database) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+
+	err := db.pool().QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1 AND expires_at > $2);",
+		jti,
+		db.timeProvider.GetTime()).Scan(&revoked)
+
+	return revoked, err
+}
+
+func (db *Database) CountPwnedPassword(ctx context.Context, prefix string, suffix string) (int, error) {
+	var count int
+
+	err := db.pool().QueryRowContext(ctx, "SELECT p.count FROM pwned_passwords p WHERE p.prefix = $1 AND p.suffix = $2;",
+		prefix,
+		suffix).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return count, err
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type Credentials interface {
+	GetConnStr(ctx context.Context) (string, error)
+}
@@ -1,10 +1,27 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 )
 
 type Database interface {
-	CheckIfCPFIsInUse(cpf string) (bool, error)
-	PersistUser(user entities.User) error
+	FindByID(ctx context.Context, id string) (entities.User, error)
+	FindByDocument(ctx context.Context, documentType int, documentId string) (entities.User, error)
+	ListUsers(ctx context.Context, filter entities.UserFilter) (entities.UserPage, error)
+	PersistUser(ctx context.Context, user entities.User) error
+	UpgradeUser(ctx context.Context, user entities.User) error
+	RehashPassword(ctx context.Context, userId string, rehash func(currentHash string) (string, error)) error
+
+	PersistRefreshToken(ctx context.Context, token entities.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (entities.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, current entities.RefreshToken, next entities.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string) error
+
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	CountPwnedPassword(ctx context.Context, prefix string, suffix string) (int, error)
 }
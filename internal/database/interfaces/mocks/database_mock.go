@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	entities "github.com/jfelipearaujo-org/lambda-register/internal/entities"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockDatabase is an autogenerated mock type for the Database type
@@ -13,27 +17,167 @@ type MockDatabase struct {
 	mock.Mock
 }
 
-// CheckIfCPFIsInUse provides a mock function with given fields: cpf
-func (_m *MockDatabase) CheckIfCPFIsInUse(cpf string) (bool, error) {
-	ret := _m.Called(cpf)
+// CountPwnedPassword provides a mock function with given fields: ctx, prefix, suffix
+func (_m *MockDatabase) CountPwnedPassword(ctx context.Context, prefix string, suffix string) (int, error) {
+	ret := _m.Called(ctx, prefix, suffix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPwnedPassword")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, prefix, suffix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, prefix, suffix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prefix, suffix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByDocument provides a mock function with given fields: ctx, documentType, documentId
+func (_m *MockDatabase) FindByDocument(ctx context.Context, documentType int, documentId string) (entities.User, error) {
+	ret := _m.Called(ctx, documentType, documentId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckIfCPFIsInUse")
+		panic("no return value specified for FindByDocument")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (entities.User, error)); ok {
+		return rf(ctx, documentType, documentId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) entities.User); ok {
+		r0 = rf(ctx, documentType, documentId)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, documentType, documentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) FindByID(ctx context.Context, id string) (entities.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entities.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshToken provides a mock function with given fields: ctx, tokenHash
+func (_m *MockDatabase) GetRefreshToken(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshToken")
+	}
+
+	var r0 entities.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entities.RefreshToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entities.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti
+func (_m *MockDatabase) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
 	}
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return rf(cpf)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(cpf)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(cpf)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) ListUsers(ctx context.Context, filter entities.UserFilter) (entities.UserPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 entities.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.UserFilter) (entities.UserPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entities.UserFilter) entities.UserPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(entities.UserPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entities.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -41,17 +185,125 @@ func (_m *MockDatabase) CheckIfCPFIsInUse(cpf string) (bool, error) {
 	return r0, r1
 }
 
-// PersistUser provides a mock function with given fields: user
-func (_m *MockDatabase) PersistUser(user entities.User) error {
-	ret := _m.Called(user)
+// PersistRefreshToken provides a mock function with given fields: ctx, token
+func (_m *MockDatabase) PersistRefreshToken(ctx context.Context, token entities.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersistRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistUser provides a mock function with given fields: ctx, user
+func (_m *MockDatabase) PersistUser(ctx context.Context, user entities.User) error {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PersistUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entities.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, entities.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RehashPassword provides a mock function with given fields: ctx, userId, rehash
+func (_m *MockDatabase) RehashPassword(ctx context.Context, userId string, rehash func(string) (string, error)) error {
+	ret := _m.Called(ctx, userId, rehash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RehashPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) (string, error)) error); ok {
+		r0 = rf(ctx, userId, rehash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: ctx, familyId
+func (_m *MockDatabase) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	ret := _m.Called(ctx, familyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeToken provides a mock function with given fields: ctx, jti, expiresAt
+func (_m *MockDatabase) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, jti, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, jti, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, current, next
+func (_m *MockDatabase) RotateRefreshToken(ctx context.Context, current entities.RefreshToken, next entities.RefreshToken) error {
+	ret := _m.Called(ctx, current, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.RefreshToken, entities.RefreshToken) error); ok {
+		r0 = rf(ctx, current, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpgradeUser provides a mock function with given fields: ctx, user
+func (_m *MockDatabase) UpgradeUser(ctx context.Context, user entities.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
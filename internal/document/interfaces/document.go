@@ -0,0 +1,10 @@
+package interfaces
+
+type Document interface {
+	Clean() string
+	IsValid() bool
+	Validate() error
+	Format() string
+	Mask() string
+	Type() int
+}
@@ -0,0 +1,87 @@
+package document
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/cnpj"
+	"github.com/jfelipearaujo-org/lambda-register/internal/cpf"
+	"github.com/jfelipearaujo-org/lambda-register/internal/document/interfaces"
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/passport"
+)
+
+const (
+	NAME_CPF      = "cpf"
+	NAME_CNPJ     = "cnpj"
+	NAME_PASSPORT = "passport"
+)
+
+var (
+	ErrUnsupportedDocumentType = errors.New("unsupported document type")
+)
+
+type Factory func(value string) interfaces.Document
+
+type Registry struct {
+	factories map[int]Factory
+	types     map[string]int
+}
+
+func NewRegistry() Registry {
+	return Registry{
+		factories: map[int]Factory{},
+		types:     map[string]int{},
+	}
+}
+
+// NewDefaultRegistry knows every document accepted from the customers, the
+// CPF and CNPJ for brazilians and the passport for foreigners
+func NewDefaultRegistry() Registry {
+	registry := NewRegistry()
+
+	registry.Register(NAME_CPF, entities.DOCUMENT_TYPE_CPF, func(value string) interfaces.Document {
+		document := cpf.NewCPF(value)
+		return &document
+	})
+	registry.Register(NAME_CNPJ, entities.DOCUMENT_TYPE_CNPJ, func(value string) interfaces.Document {
+		document := cnpj.NewCNPJ(value)
+		return &document
+	})
+	registry.Register(NAME_PASSPORT, entities.DOCUMENT_TYPE_PASSPORT, func(value string) interfaces.Document {
+		document := passport.NewPassport(value)
+		return &document
+	})
+
+	return registry
+}
+
+func (r Registry) Register(name string, documentType int, factory Factory) {
+	r.factories[documentType] = factory
+	r.types[strings.ToLower(name)] = documentType
+}
+
+// New builds a document of a persisted type, like the ones stored with the users
+func (r Registry) New(documentType int, value string) (interfaces.Document, error) {
+	factory, ok := r.factories[documentType]
+	if !ok {
+		return nil, ErrUnsupportedDocumentType
+	}
+
+	return factory(value), nil
+}
+
+// Parse builds a document from the type name informed by the customers, a
+// missing name is taken as a CPF to keep the older clients working
+func (r Registry) Parse(name string, value string) (interfaces.Document, error) {
+	if name == "" {
+		name = NAME_CPF
+	}
+
+	documentType, ok := r.types[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, ErrUnsupportedDocumentType
+	}
+
+	return r.New(documentType, value)
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		value      string
+		wantType   int
+		wantFormat string
+		wantValid  bool
+		wantErr    error
+	}{
+		{
+			name:       "Parse a CPF when the type is missing",
+			value:      "21848631065",
+			wantType:   entities.DOCUMENT_TYPE_CPF,
+			wantFormat: "218.486.310-65",
+			wantValid:  true,
+		},
+		{
+			name:       "Parse a CPF",
+			kind:       "cpf",
+			value:      "218.486.310-65",
+			wantType:   entities.DOCUMENT_TYPE_CPF,
+			wantFormat: "218.486.310-65",
+			wantValid:  true,
+		},
+		{
+			name:       "Parse a CNPJ ignoring the case of the type",
+			kind:       "CNPJ",
+			value:      "12abc34501de35",
+			wantType:   entities.DOCUMENT_TYPE_CNPJ,
+			wantFormat: "12.ABC.345/01DE-35",
+			wantValid:  true,
+		},
+		{
+			name:       "Parse a passport",
+			kind:       "passport",
+			value:      "usa:X1234567",
+			wantType:   entities.DOCUMENT_TYPE_PASSPORT,
+			wantFormat: "USA:X1234567",
+			wantValid:  true,
+		},
+		{
+			name:       "Parse an invalid CPF",
+			kind:       "cpf",
+			value:      "123",
+			wantType:   entities.DOCUMENT_TYPE_CPF,
+			wantFormat: "123",
+			wantValid:  false,
+		},
+		{
+			name:    "Do not parse an unsupported type",
+			kind:    "rg",
+			value:   "123456789",
+			wantErr: ErrUnsupportedDocumentType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			registry := NewDefaultRegistry()
+
+			// Act
+			got, err := registry.Parse(tt.kind, tt.value)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.Type())
+			assert.Equal(t, tt.wantFormat, got.Format())
+			assert.Equal(t, tt.wantValid, got.IsValid())
+		})
+	}
+}
+
+func TestRegistry_New(t *testing.T) {
+	t.Run("Should build a document from its persisted type", func(t *testing.T) {
+		// Arrange
+		registry := NewDefaultRegistry()
+
+		// Act
+		got, err := registry.New(entities.DOCUMENT_TYPE_CNPJ, "12.ABC.345/01DE-35")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "12ABC34501DE35", got.Clean())
+		assert.Equal(t, "12**********35", got.Mask())
+	})
+
+	t.Run("Should return an error when the type is not registered", func(t *testing.T) {
+		// Arrange
+		registry := NewRegistry()
+
+		// Act
+		_, err := registry.New(entities.DOCUMENT_TYPE_CPF, "218.486.310-65")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnsupportedDocumentType)
+	})
+}
@@ -0,0 +1,9 @@
+package entities
+
+import "github.com/golang-jwt/jwt/v5"
+
+type Claims struct {
+	jwt.RegisteredClaims
+	IsAnonymous bool   `json:"is_anonymous"`
+	Document    string `json:"document,omitempty"`
+}
@@ -0,0 +1,7 @@
+package entities
+
+const (
+	DOCUMENT_TYPE_CPF      = 1
+	DOCUMENT_TYPE_CNPJ     = 2
+	DOCUMENT_TYPE_PASSPORT = 3
+)
@@ -0,0 +1,11 @@
+package entities
+
+import "errors"
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrDocumentAlreadyInUse = errors.New("document already in use")
+	ErrInvalidUserFilter    = errors.New("invalid user filter")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token already used")
+)
@@ -0,0 +1,55 @@
+package entities
+
+type IntrospectionRequest struct {
+	Token         string `json:"token"`
+	TokenTypeHint string `json:"token_type_hint"`
+}
+
+// Introspection follows the response of RFC 7662, inactive tokens only carry
+// the active member
+type Introspection struct {
+	Active      bool     `json:"active"`
+	TokenType   string   `json:"token_type,omitempty"`
+	Subject     string   `json:"sub,omitempty"`
+	Issuer      string   `json:"iss,omitempty"`
+	Audience    []string `json:"aud,omitempty"`
+	ExpiresAt   int64    `json:"exp,omitempty"`
+	IssuedAt    int64    `json:"iat,omitempty"`
+	NotBefore   int64    `json:"nbf,omitempty"`
+	JwtId       string   `json:"jti,omitempty"`
+	IsAnonymous *bool    `json:"is_anonymous,omitempty"`
+}
+
+func NewInactiveIntrospection() Introspection {
+	return Introspection{
+		Active: false,
+	}
+}
+
+func NewIntrospection(claims Claims) Introspection {
+	isAnonymous := claims.IsAnonymous
+
+	introspection := Introspection{
+		Active:      true,
+		TokenType:   "Bearer",
+		Subject:     claims.Subject,
+		Issuer:      claims.Issuer,
+		Audience:    claims.Audience,
+		JwtId:       claims.ID,
+		IsAnonymous: &isAnonymous,
+	}
+
+	if claims.ExpiresAt != nil {
+		introspection.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Unix()
+	}
+
+	if claims.NotBefore != nil {
+		introspection.NotBefore = claims.NotBefore.Unix()
+	}
+
+	return introspection
+}
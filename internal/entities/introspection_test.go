@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIntrospection(t *testing.T) {
+	now := time.Date(2024, 4, 13, 23, 37, 11, 0, time.UTC)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			Issuer:    "iss",
+			Subject:   "1",
+			Audience:  jwt.ClaimStrings{"aud"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		IsAnonymous: false,
+	}
+
+	isAnonymous := false
+
+	got := NewIntrospection(claims)
+
+	assert.Equal(t, Introspection{
+		Active:      true,
+		TokenType:   "Bearer",
+		Subject:     "1",
+		Issuer:      "iss",
+		Audience:    []string{"aud"},
+		ExpiresAt:   now.Add(time.Hour).Unix(),
+		IssuedAt:    now.Unix(),
+		NotBefore:   now.Unix(),
+		JwtId:       "jti",
+		IsAnonymous: &isAnonymous,
+	}, got)
+}
+
+func TestNewInactiveIntrospection(t *testing.T) {
+	got := NewInactiveIntrospection()
+
+	assert.Equal(t, Introspection{Active: false}, got)
+}
@@ -0,0 +1,13 @@
+package entities
+
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func NewPolicyViolation(rule string, message string) PolicyViolation {
+	return PolicyViolation{
+		Rule:    rule,
+		Message: message,
+	}
+}
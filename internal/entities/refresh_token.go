@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	REFRESH_TOKEN_TTL = time.Hour * 24 * 30
+)
+
+type RefreshToken struct {
+	Id        string     `json:"id"`
+	FamilyId  string     `json:"family_id"`
+	UserId    string     `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+func NewRefreshToken(familyId, userId, tokenHash string, expiresAt time.Time) RefreshToken {
+	return RefreshToken{
+		Id:        uuid.NewString(),
+		FamilyId:  familyId,
+		UserId:    userId,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (t RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+func (t RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	expiresAt := time.Date(2024, 4, 13, 23, 37, 11, 0, time.UTC)
+
+	got := NewRefreshToken("family", "user", "hash", expiresAt)
+
+	if err := uuid.Validate(got.Id); err != nil {
+		t.Errorf("NewRefreshToken().Id = %v, want a valid UUID, got %v", got.Id, err)
+	}
+
+	if got.FamilyId != "family" || got.UserId != "user" || got.TokenHash != "hash" || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("NewRefreshToken() = %v, want the given values", got)
+	}
+
+	if got.IsUsed() || got.IsRevoked() {
+		t.Errorf("NewRefreshToken() = %v, want a fresh token", got)
+	}
+}
+
+func TestRefreshToken_IsExpired(t *testing.T) {
+	expiresAt := time.Date(2024, 4, 13, 23, 37, 11, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "Should return false before the expiration",
+			now:  expiresAt.Add(-time.Second),
+			want: false,
+		},
+		{
+			name: "Should return true at the expiration",
+			now:  expiresAt,
+			want: true,
+		},
+		{
+			name: "Should return true after the expiration",
+			now:  expiresAt.Add(time.Second),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := RefreshToken{ExpiresAt: expiresAt}
+			if got := token.IsExpired(tt.now); got != tt.want {
+				t.Errorf("RefreshToken.IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
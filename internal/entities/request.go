@@ -1,18 +1,21 @@
 package entities
 
-const (
-	MINIMUM_PASSWORD_LENGTH = 8
-)
-
+// Request carries the customer credentials, the document is informed with its
+// type, and the issuing country for passports, while the cpf and cnpj fields
+// are kept for the older clients
 type Request struct {
-	CPF      string `json:"cpf"`
-	Password string `json:"pass"`
+	DocumentType    string `json:"document_type"`
+	Document        string `json:"document"`
+	DocumentCountry string `json:"document_country"`
+	CPF             string `json:"cpf"`
+	CNPJ            string `json:"cnpj"`
+	Password        string `json:"pass"`
 }
 
 func (r Request) IsAnonymous() bool {
-	return r.CPF == "" && r.Password == ""
+	return r.Document == "" && r.DocumentCountry == "" && r.CPF == "" && r.CNPJ == "" && r.Password == ""
 }
 
-func (r Request) IsPasswordWithMinimumLength() bool {
-	return len(r.Password) >= MINIMUM_PASSWORD_LENGTH
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
@@ -6,7 +6,9 @@ import (
 
 func TestRequest_IsAnonymous(t *testing.T) {
 	type fields struct {
+		Document string
 		CPF      string
+		CNPJ     string
 		Password string
 	}
 	tests := []struct {
@@ -31,55 +33,23 @@ func TestRequest_IsAnonymous(t *testing.T) {
 			want: false,
 		},
 		{
-			name: "Should return false when password is not empty",
+			name: "Should return false when cnpj is not empty",
 			fields: fields{
-				CPF:      "",
-				Password: "123",
+				CNPJ:     "123",
+				Password: "",
 			},
 			want: false,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := Request{
-				CPF:      tt.fields.CPF,
-				Password: tt.fields.Password,
-			}
-			if got := r.IsAnonymous(); got != tt.want {
-				t.Errorf("Request.IsAnonymous() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRequest_IsPasswordWithMinimumLength(t *testing.T) {
-	type fields struct {
-		CPF      string
-		Password string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   bool
-	}{
-		{
-			name: "Should return true when password is valid",
-			fields: fields{
-				CPF:      "",
-				Password: "12345678",
-			},
-			want: true,
-		},
 		{
-			name: "Should return false when password is empty",
+			name: "Should return false when document is not empty",
 			fields: fields{
-				CPF:      "",
+				Document: "123",
 				Password: "",
 			},
 			want: false,
 		},
 		{
-			name: "Should return false when password is less than minimum length",
+			name: "Should return false when password is not empty",
 			fields: fields{
 				CPF:      "",
 				Password: "123",
@@ -90,11 +60,13 @@ func TestRequest_IsPasswordWithMinimumLength(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := Request{
+				Document: tt.fields.Document,
 				CPF:      tt.fields.CPF,
+				CNPJ:     tt.fields.CNPJ,
 				Password: tt.fields.Password,
 			}
-			if got := r.IsPasswordWithMinimumLength(); got != tt.want {
-				t.Errorf("Request.IsPasswordWithMinimumLength() = %v, want %v", got, tt.want)
+			if got := r.IsAnonymous(); got != tt.want {
+				t.Errorf("Request.IsAnonymous() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -1,7 +1,9 @@
 package entities
 
 type Response struct {
-	Status      int    `json:"status"`
-	Message     string `json:"message"`
-	AccessToken string `json:"access_token,omitempty"`
+	Status       int               `json:"status"`
+	Message      string            `json:"message"`
+	AccessToken  string            `json:"access_token,omitempty"`
+	RefreshToken string            `json:"refresh_token,omitempty"`
+	Errors       []PolicyViolation `json:"errors,omitempty"`
 }
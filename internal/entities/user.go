@@ -1,26 +1,53 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type User struct {
-	Id          string `json:"id"`
-	DocumentId  string `json:"document_id"`
-	Password    string `json:"password"`
-	IsAnonymous bool   `json:"is_anonymous"`
+	Id           string    `json:"id"`
+	DocumentId   string    `json:"document_id"`
+	DocumentType int       `json:"document_type"`
+	Password     string    `json:"-"`
+	IsAnonymous  bool      `json:"is_anonymous"`
+	FiscalRegion *int      `json:"fiscal_region"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UserFilter narrows the listing of users, the zero value of each field does
+// not filter. CreatedFrom is inclusive and CreatedTo is exclusive
+type UserFilter struct {
+	IsAnonymous *bool
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Limit       int
+	Offset      int
+}
+
+// UserPage is a page of the users listing, the users are never serialized
+// with the password hash
+type UserPage struct {
+	Users   []User `json:"users"`
+	HasMore bool   `json:"has_more"`
 }
 
 func NewAnonymousUser() User {
 	return User{
-		Id:          uuid.NewString(),
-		IsAnonymous: true,
+		Id:           uuid.NewString(),
+		DocumentType: DOCUMENT_TYPE_CPF,
+		IsAnonymous:  true,
 	}
 }
 
-func NewUser(documentId, password string) User {
+func NewUser(documentId string, documentType int, password string) User {
 	return User{
-		Id:          uuid.NewString(),
-		DocumentId:  documentId,
-		Password:    password,
-		IsAnonymous: false,
+		Id:           uuid.NewString(),
+		DocumentId:   documentId,
+		DocumentType: documentType,
+		Password:     password,
+		IsAnonymous:  false,
 	}
 }
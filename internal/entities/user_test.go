@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -29,8 +31,9 @@ func TestNewAnonymousUser(t *testing.T) {
 
 func TestNewUser(t *testing.T) {
 	type args struct {
-		documentId string
-		password   string
+		documentId   string
+		documentType int
+		password     string
 	}
 	tests := []struct {
 		name string
@@ -40,19 +43,21 @@ func TestNewUser(t *testing.T) {
 		{
 			name: "Should return a new user",
 			args: args{
-				documentId: "123",
-				password:   "123456",
+				documentId:   "123",
+				documentType: DOCUMENT_TYPE_CNPJ,
+				password:     "123456",
 			},
 			want: User{
-				DocumentId:  "123",
-				Password:    "123456",
-				IsAnonymous: false,
+				DocumentId:   "123",
+				DocumentType: DOCUMENT_TYPE_CNPJ,
+				Password:     "123456",
+				IsAnonymous:  false,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewUser(tt.args.documentId, tt.args.password)
+			got := NewUser(tt.args.documentId, tt.args.documentType, tt.args.password)
 
 			if err := uuid.Validate(got.Id); err != nil {
 				t.Errorf("NewUser().Id = %v, want a valid UUIDm got %v", got.Id, err)
@@ -62,6 +67,10 @@ func TestNewUser(t *testing.T) {
 				t.Errorf("NewUser().DocumentId = %v, want %v", got.DocumentId, tt.want.DocumentId)
 			}
 
+			if got.DocumentType != tt.want.DocumentType {
+				t.Errorf("NewUser().DocumentType = %v, want %v", got.DocumentType, tt.want.DocumentType)
+			}
+
 			if got.Password != tt.want.Password {
 				t.Errorf("NewUser().Password = %v, want %v", got.Password, tt.want.Password)
 			}
@@ -72,3 +81,21 @@ func TestNewUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserPage_MarshalJSON(t *testing.T) {
+	// Arrange
+	page := UserPage{
+		Users: []User{NewUser("218.486.310-65", DOCUMENT_TYPE_CPF, "hash")},
+	}
+
+	// Act
+	got, err := json.Marshal(page)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(got), "password") || strings.Contains(string(got), "hash") {
+		t.Errorf("json.Marshal() = %s, want the password hash left out", got)
+	}
+}
@@ -1,37 +1,56 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
 	"github.com/jfelipearaujo-org/lambda-register/internal/cpf"
 	db_interface "github.com/jfelipearaujo-org/lambda-register/internal/database/interfaces"
+	"github.com/jfelipearaujo-org/lambda-register/internal/document"
+	document_interface "github.com/jfelipearaujo-org/lambda-register/internal/document/interfaces"
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 	hash_interface "github.com/jfelipearaujo-org/lambda-register/internal/hashs/interfaces"
+	"github.com/jfelipearaujo-org/lambda-register/internal/passport"
+	policy_interface "github.com/jfelipearaujo-org/lambda-register/internal/policy/interfaces"
+	provider_interface "github.com/jfelipearaujo-org/lambda-register/internal/providers/interfaces"
 	"github.com/jfelipearaujo-org/lambda-register/internal/router"
 	token_interface "github.com/jfelipearaujo-org/lambda-register/internal/token/interfaces"
 )
 
 type Handler struct {
-	db     db_interface.Database
-	hasher hash_interface.Hasher
-	jwt    token_interface.Token
+	db           db_interface.Database
+	hasher       hash_interface.Hasher
+	jwt          token_interface.Token
+	timeProvider provider_interface.TimeProvider
+	policy       policy_interface.Policy
+	documents    document.Registry
+	clients      IntrospectionClients
 }
 
 func NewHandler(
 	db db_interface.Database,
 	hasher hash_interface.Hasher,
 	jwt token_interface.Token,
+	timeProvider provider_interface.TimeProvider,
+	policy policy_interface.Policy,
+	clients IntrospectionClients,
 ) Handler {
 	return Handler{
-		db:     db,
-		hasher: hasher,
-		jwt:    jwt,
+		db:           db,
+		hasher:       hasher,
+		jwt:          jwt,
+		timeProvider: timeProvider,
+		policy:       policy,
+		documents:    document.NewDefaultRegistry(),
+		clients:      clients,
 	}
 }
 
-func (h Handler) CrateUser(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+func (h Handler) CrateUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	var request entities.Request
 	if err := json.Unmarshal([]byte(req.Body), &request); err != nil {
 		return router.InvalidRequestBody(), nil
@@ -42,45 +61,455 @@ func (h Handler) CrateUser(req events.APIGatewayProxyRequest) (events.APIGateway
 	if request.IsAnonymous() {
 		user = entities.NewAnonymousUser()
 	} else {
-		cpf := cpf.NewCPF(request.CPF)
+		document, hashedPassword, resp, ok := h.checkCredentials(ctx, request)
+		if !ok {
+			return resp, nil
+		}
+
+		user = entities.NewUser(document.Format(), document.Type(), hashedPassword)
+		user.FiscalRegion = fiscalRegionOf(document)
+	}
 
-		if !cpf.IsValid() {
+	if err := h.db.PersistUser(ctx, user); err != nil {
+		if errors.Is(err, entities.ErrDocumentAlreadyInUse) {
 			return router.InvalidCPFOrPassword(), nil
 		}
 
-		if !request.IsPasswordWithMinimumLength() {
+		slog.Error("error persisting user", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	return h.issueTokens(ctx, user), nil
+}
+
+func (h Handler) Login(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var request entities.Request
+	if err := json.Unmarshal([]byte(req.Body), &request); err != nil {
+		return router.InvalidRequestBody(), nil
+	}
+
+	document, ok := h.documentOf(request)
+
+	if !ok || request.Password == "" {
+		return router.InvalidCPFOrPassword(), nil
+	}
+
+	// unlike the registration, the reason is not told, it would reveal which
+	// documents are denylisted to an unauthenticated caller
+	if err := document.Validate(); err != nil {
+		return router.InvalidCPFOrPassword(), nil
+	}
+
+	user, err := h.db.FindByDocument(ctx, document.Type(), document.Format())
+	if err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			// spends the time of a password check, otherwise the response time
+			// tells whether the document has an account
+			if _, err := h.hasher.VerifyPassword(ctx, request.Password, h.hasher.DummyHash()); err != nil {
+				slog.Error("error verifying password", "error", err)
+				return serverError(ctx, err), nil
+			}
+
 			return router.InvalidCPFOrPassword(), nil
 		}
 
-		cpfInUse, err := h.db.CheckIfCPFIsInUse(cpf.String())
-		if err != nil {
-			slog.Error("error checking if cpf is in use", "error", err)
-			return router.InternalServerError(), nil
+		slog.Error("error getting user by document", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	matches, err := h.hasher.VerifyPassword(ctx, request.Password, user.Password)
+	if err != nil {
+		slog.Error("error verifying password", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	if !matches {
+		return router.InvalidCPFOrPassword(), nil
+	}
+
+	if h.hasher.NeedsRehash(user.Password) {
+		h.rehashPassword(ctx, user, request.Password)
+	}
+
+	return h.issueTokens(ctx, user), nil
+}
+
+func (h Handler) UpgradeUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	tokenString, ok := router.GetBearerToken(req)
+	if !ok {
+		return router.Unauthorized(), nil
+	}
+
+	claims, err := h.jwt.VerifyJwtToken(ctx, tokenString)
+	if err != nil {
+		return unauthorized(ctx, err), nil
+	}
+
+	id := router.PathParam(req, "id")
+	if claims.Subject != id {
+		return router.Forbidden(), nil
+	}
+
+	var request entities.Request
+	if err := json.Unmarshal([]byte(req.Body), &request); err != nil {
+		return router.InvalidRequestBody(), nil
+	}
+
+	document, hashedPassword, resp, ok := h.checkCredentials(ctx, request)
+	if !ok {
+		return resp, nil
+	}
+
+	user := entities.User{
+		Id:           id,
+		DocumentId:   document.Format(),
+		DocumentType: document.Type(),
+		Password:     hashedPassword,
+		IsAnonymous:  false,
+		FiscalRegion: fiscalRegionOf(document),
+	}
+
+	if err := h.db.UpgradeUser(ctx, user); err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			return router.NotFound(), nil
 		}
 
-		if cpfInUse {
+		if errors.Is(err, entities.ErrDocumentAlreadyInUse) {
 			return router.InvalidCPFOrPassword(), nil
 		}
 
-		hashedPassword, err := h.hasher.HashPassword(request.Password)
+		slog.Error("error upgrading user", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	return h.issueTokens(ctx, user), nil
+}
+
+func (h Handler) RefreshToken(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var request entities.RefreshTokenRequest
+	if err := json.Unmarshal([]byte(req.Body), &request); err != nil || request.RefreshToken == "" {
+		return router.InvalidRequestBody(), nil
+	}
+
+	current, err := h.db.GetRefreshToken(ctx, h.jwt.HashRefreshToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, entities.ErrRefreshTokenNotFound) {
+			return router.Unauthorized(), nil
+		}
+
+		slog.Error("error getting refresh token", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	if current.IsRevoked() {
+		return router.Unauthorized(), nil
+	}
+
+	if current.IsUsed() {
+		h.revokeRefreshTokenFamily(ctx, current)
+		return router.Unauthorized(), nil
+	}
+
+	if current.IsExpired(h.timeProvider.GetTime()) {
+		return router.Unauthorized(), nil
+	}
+
+	user, err := h.db.FindByID(ctx, current.UserId)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			return router.Unauthorized(), nil
+		}
+
+		slog.Error("error getting user by id", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	accessToken, err := h.jwt.CreateJwtToken(user)
+	if err != nil {
+		slog.Error("error creating jwt token", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	refreshToken, next, err := h.newRefreshToken(user, current.FamilyId)
+	if err != nil {
+		slog.Error("error creating refresh token", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	if err := h.db.RotateRefreshToken(ctx, current, next); err != nil {
+		if errors.Is(err, entities.ErrRefreshTokenReused) {
+			h.revokeRefreshTokenFamily(ctx, current)
+			return router.Unauthorized(), nil
+		}
+
+		slog.Error("error rotating refresh token", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	return router.SuccessWithRefreshToken(accessToken, refreshToken), nil
+}
+
+// Logout revokes the access token until it expires and, when informed, the
+// refresh token family started at the same login
+func (h Handler) Logout(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	tokenString, ok := router.GetBearerToken(req)
+	if !ok {
+		return router.Unauthorized(), nil
+	}
+
+	claims, err := h.jwt.VerifyJwtToken(ctx, tokenString)
+	if err != nil {
+		return unauthorized(ctx, err), nil
+	}
+
+	var request entities.RefreshTokenRequest
+	if req.Body != "" {
+		if err := json.Unmarshal([]byte(req.Body), &request); err != nil {
+			return router.InvalidRequestBody(), nil
+		}
+	}
+
+	var refreshToken entities.RefreshToken
+	if request.RefreshToken != "" {
+		refreshToken, err = h.db.GetRefreshToken(ctx, h.jwt.HashRefreshToken(request.RefreshToken))
+		if err != nil && !errors.Is(err, entities.ErrRefreshTokenNotFound) {
+			slog.Error("error getting refresh token", "error", err)
+			return serverError(ctx, err), nil
+		}
+
+		if err == nil && refreshToken.UserId != claims.Subject {
+			return router.Forbidden(), nil
+		}
+	}
+
+	if err := h.db.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		slog.Error("error revoking access token", "error", err)
+		return serverError(ctx, err), nil
+	}
+
+	if refreshToken.FamilyId != "" {
+		if err := h.db.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyId); err != nil {
+			slog.Error("error revoking refresh token family", "error", err)
+			return serverError(ctx, err), nil
+		}
+	}
+
+	return router.NoContent(), nil
+}
+
+// Introspect follows RFC 7662, accepting the token as a form parameter or as
+// JSON and answering whether it is active. Only the introspection clients may
+// call it
+func (h Handler) Introspect(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !h.clients.Authenticate(req) {
+		return router.Unauthorized(), nil
+	}
+
+	var request entities.IntrospectionRequest
+
+	if router.IsJson(req) {
+		body, err := router.GetBody(req)
+		if err != nil {
+			return router.InvalidRequestBody(), nil
+		}
+
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			return router.InvalidRequestBody(), nil
+		}
+	} else {
+		values, err := router.GetFormValues(req)
 		if err != nil {
-			slog.Error("error hashing password", "error", err)
-			return router.InternalServerError(), nil
+			return router.InvalidRequestBody(), nil
 		}
 
-		user = entities.NewUser(cpf.String(), hashedPassword)
+		request.Token = values.Get("token")
+		request.TokenTypeHint = values.Get("token_type_hint")
 	}
 
-	if err := h.db.PersistUser(user); err != nil {
-		slog.Error("error persisting user", "error", err)
-		return router.InternalServerError(), nil
+	if request.Token == "" {
+		return router.InvalidRequestBody(), nil
+	}
+
+	claims, err := h.jwt.VerifyJwtToken(ctx, request.Token)
+	if err != nil && router.TimedOut(ctx, err) {
+		return router.Timeout(), nil
 	}
 
-	token, err := h.jwt.CreateJwtToken(user)
+	if err != nil {
+		return router.Introspection(entities.NewInactiveIntrospection()), nil
+	}
+
+	return router.Introspection(entities.NewIntrospection(claims)), nil
+}
+
+func (h Handler) Jwks(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return router.JwkSet(h.jwt.GetJwks()), nil
+}
+
+// issueTokens creates the access token and starts a new refresh token family
+// for the user
+func (h Handler) issueTokens(ctx context.Context, user entities.User) events.APIGatewayProxyResponse {
+	accessToken, err := h.jwt.CreateJwtToken(user)
 	if err != nil {
 		slog.Error("error creating jwt token", "error", err)
-		return router.InternalServerError(), nil
+		return serverError(ctx, err)
+	}
+
+	refreshToken, token, err := h.newRefreshToken(user, uuid.NewString())
+	if err != nil {
+		slog.Error("error creating refresh token", "error", err)
+		return serverError(ctx, err)
+	}
+
+	if err := h.db.PersistRefreshToken(ctx, token); err != nil {
+		slog.Error("error persisting refresh token", "error", err)
+		return serverError(ctx, err)
+	}
+
+	return router.SuccessWithRefreshToken(accessToken, refreshToken)
+}
+
+func (h Handler) newRefreshToken(user entities.User, familyId string) (string, entities.RefreshToken, error) {
+	refreshToken, tokenHash, err := h.jwt.CreateRefreshToken()
+	if err != nil {
+		return "", entities.RefreshToken{}, err
+	}
+
+	expiresAt := h.timeProvider.GetTime().Add(entities.REFRESH_TOKEN_TTL)
+
+	return refreshToken, entities.NewRefreshToken(familyId, user.Id, tokenHash, expiresAt), nil
+}
+
+// rehashPassword upgrades the stored hash to the current algorithm and
+// parameters. The row is read and updated in one transaction, and a hash that
+// changed since the login is kept, since the password was changed meanwhile.
+// A failure is only logged: the user is already authenticated and the old hash
+// is still valid, so the rehash is tried again on the next login
+func (h Handler) rehashPassword(ctx context.Context, user entities.User, password string) {
+	err := h.db.RehashPassword(ctx, user.Id, func(currentHash string) (string, error) {
+		if currentHash != user.Password {
+			return "", nil
+		}
+
+		return h.hasher.HashPassword(ctx, password)
+	})
+	if err != nil {
+		slog.Error("error rehashing password", "error", err, "user_id", user.Id)
+	}
+}
+
+// revokeRefreshTokenFamily is called when an already used refresh token is
+// presented, which means it was leaked, so every token of the family is revoked
+func (h Handler) revokeRefreshTokenFamily(ctx context.Context, token entities.RefreshToken) {
+	slog.Warn("refresh token reuse detected, revoking the token family", "family_id", token.FamilyId, "user_id", token.UserId)
+
+	if err := h.db.RevokeRefreshTokenFamily(ctx, token.FamilyId); err != nil {
+		slog.Error("error revoking refresh token family", "error", err)
+	}
+}
+
+// checkCredentials validates the document and password of a registration
+// request, returning the document and the hashed password when they can be
+// used. Documents already in use are only caught when persisting the user, by
+// the unique index of the customers table
+func (h Handler) checkCredentials(ctx context.Context, request entities.Request) (document_interface.Document, string, events.APIGatewayProxyResponse, bool) {
+	document, ok := h.documentOf(request)
+
+	if !ok {
+		return nil, "", router.InvalidCPFOrPassword(), false
+	}
+
+	if err := document.Validate(); err != nil {
+		return nil, "", router.InvalidDocument(err), false
+	}
+
+	if violations := h.policy.Validate(ctx, request.Password, document.Format()); len(violations) > 0 {
+		return nil, "", router.InvalidPassword(violations), false
+	}
+
+	hashedPassword, err := h.hasher.HashPassword(ctx, request.Password)
+	if err != nil {
+		slog.Error("error hashing password", "error", err)
+		return nil, "", serverError(ctx, err), false
+	}
+
+	return document, hashedPassword, events.APIGatewayProxyResponse{}, true
+}
+
+// documentOf returns the document informed in the request, either through the
+// document fields or through one of the legacy cpf and cnpj fields, a request
+// carrying more than one of them is rejected. The issuing country is only taken
+// for passports. The document still needs to be validated by the caller
+func (h Handler) documentOf(request entities.Request) (document_interface.Document, bool) {
+	name, value := request.DocumentType, request.Document
+	informed := 0
+
+	if request.Document != "" {
+		informed++
+	}
+
+	if request.CPF != "" {
+		name, value = document.NAME_CPF, request.CPF
+		informed++
+	}
+
+	if request.CNPJ != "" {
+		name, value = document.NAME_CNPJ, request.CNPJ
+		informed++
+	}
+
+	if informed > 1 {
+		return nil, false
+	}
+
+	if request.DocumentCountry != "" {
+		value = request.DocumentCountry + passport.COUNTRY_SEPARATOR + value
+	}
+
+	document, err := h.documents.Parse(name, value)
+	if err != nil {
+		return nil, false
+	}
+
+	if request.DocumentCountry != "" && document.Type() != entities.DOCUMENT_TYPE_PASSPORT {
+		return nil, false
+	}
+
+	return document, true
+}
+
+// fiscalRegionOf returns the fiscal region where a CPF was issued, the other
+// documents do not carry one
+func fiscalRegionOf(document document_interface.Document) *int {
+	cpf, ok := document.(*cpf.CPF)
+	if !ok {
+		return nil
+	}
+
+	region, ok := cpf.Region()
+	if !ok {
+		return nil
+	}
+
+	return &region.Code
+}
+
+// serverError answers the failure of a dependency, telling apart the ones
+// caused by the invocation running out of time
+func serverError(ctx context.Context, err error) events.APIGatewayProxyResponse {
+	if router.TimedOut(ctx, err) {
+		return router.Timeout()
+	}
+
+	return router.InternalServerError()
+}
+
+// unauthorized answers a token that could not be verified, unless the
+// verification did not finish in time
+func unauthorized(ctx context.Context, err error) events.APIGatewayProxyResponse {
+	if router.TimedOut(ctx, err) {
+		return router.Timeout()
 	}
 
-	return router.Success(token), nil
+	return router.Unauthorized()
 }
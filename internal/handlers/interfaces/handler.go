@@ -1,9 +1,17 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/aws/aws-lambda-go/events"
 )
 
 type Handler interface {
-	CrateUser(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	CrateUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	Login(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	UpgradeUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	RefreshToken(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	Logout(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	Introspect(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+	Jwks(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
 }
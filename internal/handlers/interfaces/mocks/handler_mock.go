@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	events "github.com/aws/aws-lambda-go/events"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,9 +15,9 @@ type MockHandler struct {
 	mock.Mock
 }
 
-// CrateUser provides a mock function with given fields: req
-func (_m *MockHandler) CrateUser(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	ret := _m.Called(req)
+// CrateUser provides a mock function with given fields: ctx, req
+func (_m *MockHandler) CrateUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CrateUser")
@@ -23,17 +25,185 @@ func (_m *MockHandler) CrateUser(req events.APIGatewayProxyRequest) (events.APIG
 
 	var r0 events.APIGatewayProxyResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Introspect provides a mock function with given fields: ctx, req
+func (_m *MockHandler) Introspect(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Introspect")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Jwks provides a mock function with given fields: ctx, req
+func (_m *MockHandler) Jwks(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Jwks")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, req
+func (_m *MockHandler) Login(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, req
+func (_m *MockHandler) Logout(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: ctx, req
+func (_m *MockHandler) RefreshToken(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpgradeUser provides a mock function with given fields: ctx, req
+func (_m *MockHandler) UpgradeUser(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeUser")
+	}
+
+	var r0 events.APIGatewayProxyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Get(0).(events.APIGatewayProxyResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(events.APIGatewayProxyRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, events.APIGatewayProxyRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/jfelipearaujo-org/lambda-register/internal/router"
+)
+
+// IntrospectionClients holds the secrets of the resource servers allowed to
+// introspect tokens, keyed by client id. RFC 7662 requires the endpoint to
+// authenticate its callers
+type IntrospectionClients map[string]string
+
+// NewIntrospectionClientsFromEnv loads INTROSPECTION_CLIENTS, a comma separated
+// list of client_id:secret pairs. Without clients every introspection is refused
+func NewIntrospectionClientsFromEnv() (IntrospectionClients, error) {
+	clients := IntrospectionClients{}
+
+	raw := os.Getenv("INTROSPECTION_CLIENTS")
+	if raw == "" {
+		return clients, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		id, secret, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || id == "" || secret == "" {
+			return nil, fmt.Errorf("invalid INTROSPECTION_CLIENTS entry %q", id)
+		}
+
+		clients[id] = secret
+	}
+
+	return clients, nil
+}
+
+// Authenticate checks the Basic credentials of the request, the secrets are
+// compared through their digests so the time spent does not depend on them
+func (c IntrospectionClients) Authenticate(req events.APIGatewayProxyRequest) bool {
+	id, secret, ok := router.GetBasicAuth(req)
+	if !ok {
+		return false
+	}
+
+	expected, found := c[id]
+	if !found {
+		return false
+	}
+
+	got := sha256.Sum256([]byte(secret))
+	want := sha256.Sum256([]byte(expected))
+
+	return subtle.ConstantTimeCompare(got[:], want[:]) == 1
+}
@@ -0,0 +1,127 @@
+package hashs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ALGORITHM_BCRYPT   = "bcrypt"
+	ALGORITHM_ARGON2ID = "argon2id"
+
+	DEFAULT_ALGORITHM = ALGORITHM_BCRYPT
+
+	DEFAULT_BCRYPT_COST = bcrypt.DefaultCost
+
+	// OWASP recommended minimum for argon2id: 19 MiB, 2 iterations, 1 thread
+	DEFAULT_ARGON2_MEMORY      = 19 * 1024
+	DEFAULT_ARGON2_TIME        = 2
+	DEFAULT_ARGON2_PARALLELISM = 1
+	DEFAULT_ARGON2_SALT_LENGTH = 16
+	DEFAULT_ARGON2_KEY_LENGTH  = 32
+)
+
+type Argon2Params struct {
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+
+	// Peppers keeps every pepper still needed to verify stored hashes by its
+	// version, PepperVersion selects the one used for new hashes
+	Peppers       map[string][]byte
+	PepperVersion string
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Algorithm:  DEFAULT_ALGORITHM,
+		BcryptCost: DEFAULT_BCRYPT_COST,
+		Argon2: Argon2Params{
+			Memory:      DEFAULT_ARGON2_MEMORY,
+			Time:        DEFAULT_ARGON2_TIME,
+			Parallelism: DEFAULT_ARGON2_PARALLELISM,
+			SaltLength:  DEFAULT_ARGON2_SALT_LENGTH,
+			KeyLength:   DEFAULT_ARGON2_KEY_LENGTH,
+		},
+	}
+}
+
+// NewConfigFromEnv loads the algorithm used for new hashes from HASH_ALGORITHM
+// and its parameters from HASH_BCRYPT_COST and the HASH_ARGON2_* variables.
+// The peppers come from HASH_PEPPERS, a JSON object of version to secret, and
+// the current one from HASH_PEPPER_VERSION
+func NewConfigFromEnv() (Config, error) {
+	config := NewDefaultConfig()
+
+	if raw := os.Getenv("HASH_PEPPERS"); raw != "" {
+		var peppers map[string]string
+		if err := json.Unmarshal([]byte(raw), &peppers); err != nil {
+			return Config{}, fmt.Errorf("error parsing HASH_PEPPERS: %w", err)
+		}
+
+		config.Peppers = make(map[string][]byte, len(peppers))
+		for version, pepper := range peppers {
+			config.Peppers[version] = []byte(pepper)
+		}
+	}
+
+	config.PepperVersion = os.Getenv("HASH_PEPPER_VERSION")
+
+	if algorithm := os.Getenv("HASH_ALGORITHM"); algorithm != "" {
+		config.Algorithm = algorithm
+	}
+
+	if err := parseEnvInt("HASH_BCRYPT_COST", 31, func(value uint64) {
+		config.BcryptCost = int(value)
+	}); err != nil {
+		return Config{}, err
+	}
+
+	if err := parseEnvInt("HASH_ARGON2_MEMORY", 32, func(value uint64) {
+		config.Argon2.Memory = uint32(value)
+	}); err != nil {
+		return Config{}, err
+	}
+
+	if err := parseEnvInt("HASH_ARGON2_TIME", 32, func(value uint64) {
+		config.Argon2.Time = uint32(value)
+	}); err != nil {
+		return Config{}, err
+	}
+
+	if err := parseEnvInt("HASH_ARGON2_PARALLELISM", 8, func(value uint64) {
+		config.Argon2.Parallelism = uint8(value)
+	}); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+func parseEnvInt(name string, bitSize int, set func(uint64)) error {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return nil
+	}
+
+	value, err := strconv.ParseUint(raw, 10, bitSize)
+	if err != nil || value == 0 {
+		return fmt.Errorf("invalid %s %q", name, raw)
+	}
+
+	set(value)
+
+	return nil
+}
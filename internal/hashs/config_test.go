@@ -0,0 +1,84 @@
+package hashs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("Should return the default configuration", func(t *testing.T) {
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, NewDefaultConfig(), got)
+	})
+
+	t.Run("Should load the argon2id parameters", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HASH_ALGORITHM", ALGORITHM_ARGON2ID)
+		t.Setenv("HASH_ARGON2_MEMORY", "65536")
+		t.Setenv("HASH_ARGON2_TIME", "3")
+		t.Setenv("HASH_ARGON2_PARALLELISM", "4")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, ALGORITHM_ARGON2ID, got.Algorithm)
+		assert.Equal(t, uint32(65536), got.Argon2.Memory)
+		assert.Equal(t, uint32(3), got.Argon2.Time)
+		assert.Equal(t, uint8(4), got.Argon2.Parallelism)
+	})
+
+	t.Run("Should load the bcrypt cost", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HASH_BCRYPT_COST", "12")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 12, got.BcryptCost)
+	})
+
+	t.Run("Should return an error when a parameter is invalid", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HASH_ARGON2_PARALLELISM", "256")
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should load the peppers", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HASH_PEPPERS", `{"v1":"old-pepper","v2":"new-pepper"}`)
+		t.Setenv("HASH_PEPPER_VERSION", "v2")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"v1": []byte("old-pepper"), "v2": []byte("new-pepper")}, got.Peppers)
+		assert.Equal(t, "v2", got.PepperVersion)
+	})
+
+	t.Run("Should return an error when the peppers are malformed", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HASH_PEPPERS", `["v1"]`)
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
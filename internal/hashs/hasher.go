@@ -1,15 +1,169 @@
 package hashs
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+	ErrInvalidParameters    = errors.New("invalid hash parameters")
+)
 
 type Hasher struct {
+	config    Config
+	dummyHash string
+}
+
+func NewHasher(config Config) (Hasher, error) {
+	switch config.Algorithm {
+	case ALGORITHM_BCRYPT:
+		if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+			return Hasher{}, ErrInvalidParameters
+		}
+	case ALGORITHM_ARGON2ID:
+		params := config.Argon2
+		if params.Memory == 0 || params.Time == 0 || params.Parallelism == 0 || params.SaltLength == 0 || params.KeyLength == 0 {
+			return Hasher{}, ErrInvalidParameters
+		}
+	default:
+		return Hasher{}, ErrUnsupportedAlgorithm
+	}
+
+	for version, pepper := range config.Peppers {
+		if !isValidPepperId(version) {
+			return Hasher{}, ErrInvalidPepperId
+		}
+
+		if len(pepper) == 0 {
+			return Hasher{}, ErrEmptyPepper
+		}
+	}
+
+	if _, ok := config.Peppers[config.PepperVersion]; config.PepperVersion != "" && !ok {
+		return Hasher{}, ErrUnknownPepper
+	}
+
+	hasher := Hasher{
+		config: config,
+	}
+
+	dummyHash, err := hasher.newDummyHash()
+	if err != nil {
+		return Hasher{}, err
+	}
+
+	hasher.dummyHash = dummyHash
+
+	return hasher, nil
+}
+
+// newDummyHash hashes a random password with the configured parameters, so a
+// verification against it costs the same as one against a stored hash
+func (h Hasher) newDummyHash() (string, error) {
+	password := make([]byte, 16)
+	if _, err := rand.Read(password); err != nil {
+		return "", err
+	}
+
+	return h.HashPassword(context.Background(), hex.EncodeToString(password))
 }
 
-func NewHasher() Hasher {
-	return Hasher{}
+// DummyHash is verified when there is no stored hash to compare with, so a
+// login of an unknown user takes as long as one with a wrong password
+func (h Hasher) DummyHash() string {
+	return h.dummyHash
 }
 
-func (h Hasher) HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// HashPassword gives up before hashing when the context is done, the hashing
+// itself can not be interrupted
+func (h Hasher) HashPassword(ctx context.Context, password string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if h.config.PepperVersion == "" {
+		return h.hash(password)
+	}
+
+	hashedPassword, err := h.hash(applyPepper(h.config.Peppers[h.config.PepperVersion], password))
+	if err != nil {
+		return "", err
+	}
+
+	return encodePepper(h.config.PepperVersion, hashedPassword), nil
+}
+
+// VerifyPassword checks the password against a hash of any supported algorithm
+// and any known pepper, regardless of the ones configured for new hashes
+func (h Hasher) VerifyPassword(ctx context.Context, password string, hashedPassword string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	version, inner, ok := decodePepper(hashedPassword)
+	if !ok {
+		return false, nil
+	}
+
+	if version != "" {
+		pepper, ok := h.config.Peppers[version]
+		if !ok {
+			return false, nil
+		}
+
+		password = applyPepper(pepper, password)
+	}
+
+	return verify(password, inner), nil
+}
+
+func (h Hasher) hash(password string) (string, error) {
+	if h.config.Algorithm == ALGORITHM_ARGON2ID {
+		return hashArgon2id(password, h.config.Argon2)
+	}
+
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
 	return string(bytes), err
 }
+
+func verify(password string, hashedPassword string) bool {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return verifyArgon2id(password, hashedPassword)
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) == nil
+}
+
+// NeedsRehash reports whether the hash was created with another algorithm,
+// with parameters other than the configured ones or with another pepper
+func (h Hasher) NeedsRehash(hashedPassword string) bool {
+	version, hashedPassword, ok := decodePepper(hashedPassword)
+	if !ok || version != h.config.PepperVersion {
+		return true
+	}
+
+	if h.config.Algorithm == ALGORITHM_ARGON2ID {
+		params, _, _, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return true
+		}
+
+		return params.Memory != h.config.Argon2.Memory ||
+			params.Time != h.config.Argon2.Time ||
+			params.Parallelism != h.config.Argon2.Parallelism ||
+			params.KeyLength != h.config.Argon2.KeyLength
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.config.BcryptCost
+}
@@ -1,32 +1,127 @@
 package hashs
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
+func newTestConfig(algorithm string) Config {
+	config := NewDefaultConfig()
+	config.Algorithm = algorithm
+	config.BcryptCost = bcrypt.MinCost
+	config.Argon2.Memory = 1024
+	config.Argon2.Time = 1
+
+	return config
+}
+
+func newTestHasher(t *testing.T, algorithm string) Hasher {
+	hasher, err := NewHasher(newTestConfig(algorithm))
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+
+	return hasher
+}
+
+func TestNewHasher(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*Config)
+		wantErr error
+	}{
+		{
+			name:    "Create a bcrypt hasher",
+			change:  func(c *Config) {},
+			wantErr: nil,
+		},
+		{
+			name: "Create an argon2id hasher",
+			change: func(c *Config) {
+				c.Algorithm = ALGORITHM_ARGON2ID
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Reject an unsupported algorithm",
+			change: func(c *Config) {
+				c.Algorithm = "md5"
+			},
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "Reject a bcrypt cost out of range",
+			change: func(c *Config) {
+				c.BcryptCost = 32
+			},
+			wantErr: ErrInvalidParameters,
+		},
+		{
+			name: "Reject argon2id without memory",
+			change: func(c *Config) {
+				c.Algorithm = ALGORITHM_ARGON2ID
+				c.Argon2.Memory = 0
+			},
+			wantErr: ErrInvalidParameters,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			config := NewDefaultConfig()
+			tt.change(&config)
+
+			// Act
+			_, err := NewHasher(config)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestHashPassword(t *testing.T) {
 	type args struct {
 		password string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		notWant string
-		wantErr bool
+		name       string
+		algorithm  string
+		args       args
+		notWant    string
+		wantPrefix string
+		wantErr    bool
 	}{
 		{
-			name: "Hash a password",
+			name:      "Hash a password with bcrypt",
+			algorithm: ALGORITHM_BCRYPT,
 			args: args{
 				password: "123456",
 			},
-			notWant: "123456",
-			wantErr: false,
+			notWant:    "123456",
+			wantPrefix: "$2a$04$",
+			wantErr:    false,
+		},
+		{
+			name:      "Hash a password with argon2id",
+			algorithm: ALGORITHM_ARGON2ID,
+			args: args{
+				password: "123456",
+			},
+			notWant:    "123456",
+			wantPrefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+			wantErr:    false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hasher := NewHasher()
-			got, err := hasher.HashPassword(tt.args.password)
+			hasher := newTestHasher(t, tt.algorithm)
+			got, err := hasher.HashPassword(context.Background(), tt.args.password)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HashPassword() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -34,6 +129,240 @@ func TestHashPassword(t *testing.T) {
 			if got == tt.notWant {
 				t.Errorf("HashPassword() = %v, not want %v", got, tt.notWant)
 			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("HashPassword() = %v, want prefix %v", got, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	bcryptHash, err := newTestHasher(t, ALGORITHM_BCRYPT).HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	argon2Hash, err := newTestHasher(t, ALGORITHM_ARGON2ID).HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	type args struct {
+		password       string
+		hashedPassword string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Verify a matching password hashed with bcrypt",
+			args: args{
+				password:       "12345678",
+				hashedPassword: bcryptHash,
+			},
+			want: true,
+		},
+		{
+			name: "Verify a wrong password hashed with bcrypt",
+			args: args{
+				password:       "87654321",
+				hashedPassword: bcryptHash,
+			},
+			want: false,
+		},
+		{
+			name: "Verify a matching password hashed with argon2id",
+			args: args{
+				password:       "12345678",
+				hashedPassword: argon2Hash,
+			},
+			want: true,
+		},
+		{
+			name: "Verify a wrong password hashed with argon2id",
+			args: args{
+				password:       "87654321",
+				hashedPassword: argon2Hash,
+			},
+			want: false,
+		},
+		{
+			name: "Verify a matching password against a known argon2id hash",
+			args: args{
+				password:       "password",
+				hashedPassword: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			},
+			want: true,
+		},
+		{
+			name: "Verify against a malformed hash",
+			args: args{
+				password:       "12345678",
+				hashedPassword: "abc123",
+			},
+			want: false,
+		},
+		{
+			name: "Verify against a malformed argon2id hash",
+			args: args{
+				password:       "12345678",
+				hashedPassword: "$argon2id$v=19$m=abc$salt$hash",
+			},
+			want: false,
+		},
+	}
+	for _, algorithm := range []string{ALGORITHM_BCRYPT, ALGORITHM_ARGON2ID} {
+		hasher := newTestHasher(t, algorithm)
+
+		for _, tt := range tests {
+			t.Run(algorithm+"/"+tt.name, func(t *testing.T) {
+				got, err := hasher.VerifyPassword(context.Background(), tt.args.password, tt.args.hashedPassword)
+				if err != nil {
+					t.Fatalf("VerifyPassword() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("VerifyPassword() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyPassword_ContextDone(t *testing.T) {
+	// Arrange
+	hasher := newTestHasher(t, ALGORITHM_BCRYPT)
+
+	hashedPassword, err := hasher.HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	got, err := hasher.VerifyPassword(ctx, "12345678", hashedPassword)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("VerifyPassword() error = %v, want %v", err, context.Canceled)
+	}
+	if got {
+		t.Errorf("VerifyPassword() = %v, want false", got)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptHasher := newTestHasher(t, ALGORITHM_BCRYPT)
+	argon2Hasher := newTestHasher(t, ALGORITHM_ARGON2ID)
+
+	bcryptHash, err := bcryptHasher.HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	argon2Hash, err := argon2Hasher.HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	costlierConfig := newTestConfig(ALGORITHM_BCRYPT)
+	costlierConfig.BcryptCost = bcrypt.MinCost + 1
+	costlierHasher, err := NewHasher(costlierConfig)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+
+	strongerConfig := newTestConfig(ALGORITHM_ARGON2ID)
+	strongerConfig.Argon2.Time = 2
+	strongerHasher, err := NewHasher(strongerConfig)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		hasher         Hasher
+		hashedPassword string
+		want           bool
+	}{
+		{
+			name:           "Bcrypt hash with the configured cost",
+			hasher:         bcryptHasher,
+			hashedPassword: bcryptHash,
+			want:           false,
+		},
+		{
+			name:           "Bcrypt hash with another cost",
+			hasher:         costlierHasher,
+			hashedPassword: bcryptHash,
+			want:           true,
+		},
+		{
+			name:           "Bcrypt hash when argon2id is configured",
+			hasher:         argon2Hasher,
+			hashedPassword: bcryptHash,
+			want:           true,
+		},
+		{
+			name:           "Argon2id hash with the configured parameters",
+			hasher:         argon2Hasher,
+			hashedPassword: argon2Hash,
+			want:           false,
+		},
+		{
+			name:           "Argon2id hash with other parameters",
+			hasher:         strongerHasher,
+			hashedPassword: argon2Hash,
+			want:           true,
+		},
+		{
+			name:           "Argon2id hash when bcrypt is configured",
+			hasher:         bcryptHasher,
+			hashedPassword: argon2Hash,
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hashedPassword); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDummyHash(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+	}{
+		{
+			name:      "Bcrypt",
+			algorithm: ALGORITHM_BCRYPT,
+		},
+		{
+			name:      "Argon2id",
+			algorithm: ALGORITHM_ARGON2ID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher := newTestHasher(t, tt.algorithm)
+
+			got := hasher.DummyHash()
+
+			if got == "" {
+				t.Fatalf("DummyHash() is empty")
+			}
+			if hasher.NeedsRehash(got) {
+				t.Errorf("DummyHash() = %v, not hashed with the configured parameters", got)
+			}
+			if matches, _ := hasher.VerifyPassword(context.Background(), "12345678", got); matches {
+				t.Errorf("DummyHash() = %v, matches a known password", got)
+			}
 		})
 	}
 }
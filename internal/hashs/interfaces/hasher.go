@@ -1,5 +1,10 @@
 package interfaces
 
+import "context"
+
 type Hasher interface {
-	HashPassword(password string) (string, error)
+	HashPassword(ctx context.Context, password string) (string, error)
+	VerifyPassword(ctx context.Context, password string, hashedPassword string) (bool, error)
+	NeedsRehash(hashedPassword string) bool
+	DummyHash() string
 }
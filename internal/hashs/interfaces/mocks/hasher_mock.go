@@ -2,16 +2,38 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockHasher is an autogenerated mock type for the Hasher type
 type MockHasher struct {
 	mock.Mock
 }
 
-// HashPassword provides a mock function with given fields: password
-func (_m *MockHasher) HashPassword(password string) (string, error) {
-	ret := _m.Called(password)
+// DummyHash provides a mock function with given fields:
+func (_m *MockHasher) DummyHash() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DummyHash")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// HashPassword provides a mock function with given fields: ctx, password
+func (_m *MockHasher) HashPassword(ctx context.Context, password string) (string, error) {
+	ret := _m.Called(ctx, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HashPassword")
@@ -19,17 +41,63 @@ func (_m *MockHasher) HashPassword(password string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(password)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, password)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(password)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, password)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(password)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NeedsRehash provides a mock function with given fields: hashedPassword
+func (_m *MockHasher) NeedsRehash(hashedPassword string) bool {
+	ret := _m.Called(hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(hashedPassword)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// VerifyPassword provides a mock function with given fields: ctx, password, hashedPassword
+func (_m *MockHasher) VerifyPassword(ctx context.Context, password string, hashedPassword string) (bool, error) {
+	ret := _m.Called(ctx, password, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPassword")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, password, hashedPassword)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, password, hashedPassword)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, password, hashedPassword)
 	} else {
 		r1 = ret.Error(1)
 	}
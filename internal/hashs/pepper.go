@@ -0,0 +1,51 @@
+package hashs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+const pepperPrefix = "$pepper$id="
+
+var (
+	ErrUnknownPepper   = errors.New("unknown pepper version")
+	ErrInvalidPepperId = errors.New("invalid pepper id")
+	ErrEmptyPepper     = errors.New("empty pepper")
+)
+
+// applyPepper mixes the server side secret into the password before hashing,
+// the HMAC is base64 encoded to stay under the 72 bytes bcrypt truncates at
+func applyPepper(pepper []byte, password string) string {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write([]byte(password))
+
+	return base64.RawStdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encodePepper prefixes the inner hash with the pepper version used, e.g.
+// $pepper$id=2024-01$argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+func encodePepper(version string, hashedPassword string) string {
+	return pepperPrefix + version + hashedPassword
+}
+
+// decodePepper splits a stored hash into the pepper version and the inner
+// hash, unpeppered hashes are returned as they are with an empty version
+func decodePepper(hashedPassword string) (string, string, bool) {
+	if !strings.HasPrefix(hashedPassword, pepperPrefix) {
+		return "", hashedPassword, true
+	}
+
+	version, inner, found := strings.Cut(strings.TrimPrefix(hashedPassword, pepperPrefix), "$")
+	if !found || version == "" {
+		return "", "", false
+	}
+
+	return version, "$" + inner, true
+}
+
+func isValidPepperId(id string) bool {
+	return id != "" && !strings.ContainsAny(id, "$ ")
+}
@@ -0,0 +1,165 @@
+package hashs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPepperedHasher(t *testing.T, algorithm string, version string, peppers map[string][]byte) Hasher {
+	config := newTestConfig(algorithm)
+	config.Peppers = peppers
+	config.PepperVersion = version
+
+	hasher, err := NewHasher(config)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+
+	return hasher
+}
+
+func TestNewHasher_Peppers(t *testing.T) {
+	tests := []struct {
+		name          string
+		peppers       map[string][]byte
+		pepperVersion string
+		wantErr       error
+	}{
+		{
+			name:          "Accept a known pepper version",
+			peppers:       map[string][]byte{"v1": []byte("pepper")},
+			pepperVersion: "v1",
+			wantErr:       nil,
+		},
+		{
+			name:          "Accept peppers kept only for verification",
+			peppers:       map[string][]byte{"v1": []byte("pepper")},
+			pepperVersion: "",
+			wantErr:       nil,
+		},
+		{
+			name:          "Reject an unknown pepper version",
+			peppers:       map[string][]byte{"v1": []byte("pepper")},
+			pepperVersion: "v2",
+			wantErr:       ErrUnknownPepper,
+		},
+		{
+			name:          "Reject a pepper id with a separator",
+			peppers:       map[string][]byte{"v$1": []byte("pepper")},
+			pepperVersion: "",
+			wantErr:       ErrInvalidPepperId,
+		},
+		{
+			name:          "Reject an empty pepper",
+			peppers:       map[string][]byte{"v1": {}},
+			pepperVersion: "v1",
+			wantErr:       ErrEmptyPepper,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			config := newTestConfig(ALGORITHM_BCRYPT)
+			config.Peppers = tt.peppers
+			config.PepperVersion = tt.pepperVersion
+
+			// Act
+			_, err := NewHasher(config)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHashPassword_Pepper(t *testing.T) {
+	for _, algorithm := range []string{ALGORITHM_BCRYPT, ALGORITHM_ARGON2ID} {
+		t.Run(algorithm, func(t *testing.T) {
+			// Arrange
+			hasher := newPepperedHasher(t, algorithm, "v1", map[string][]byte{"v1": []byte("pepper")})
+
+			// Act
+			got, err := hasher.HashPassword(context.Background(), "12345678")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(got, "$pepper$id=v1$"), got)
+			matches, err := hasher.VerifyPassword(context.Background(), "12345678", got)
+			assert.NoError(t, err)
+			assert.True(t, matches)
+
+			matches, err = hasher.VerifyPassword(context.Background(), "87654321", got)
+			assert.NoError(t, err)
+			assert.False(t, matches)
+			assert.False(t, hasher.NeedsRehash(got))
+		})
+	}
+}
+
+func TestVerifyPassword_PepperRotation(t *testing.T) {
+	oldHasher := newPepperedHasher(t, ALGORITHM_BCRYPT, "v1", map[string][]byte{"v1": []byte("old-pepper")})
+	plainHasher := newTestHasher(t, ALGORITHM_BCRYPT)
+
+	oldHash, err := oldHasher.HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	plainHash, err := plainHasher.HashPassword(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	rotated := newPepperedHasher(t, ALGORITHM_BCRYPT, "v2", map[string][]byte{
+		"v1": []byte("old-pepper"),
+		"v2": []byte("new-pepper"),
+	})
+
+	tests := []struct {
+		name            string
+		hasher          Hasher
+		hashedPassword  string
+		wantVerify      bool
+		wantNeedsRehash bool
+	}{
+		{
+			name:            "Verify a hash with the previous pepper",
+			hasher:          rotated,
+			hashedPassword:  oldHash,
+			wantVerify:      true,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "Verify an unpeppered hash",
+			hasher:          rotated,
+			hashedPassword:  plainHash,
+			wantVerify:      true,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "Do not verify a hash with an unknown pepper",
+			hasher:          plainHasher,
+			hashedPassword:  oldHash,
+			wantVerify:      false,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "Do not verify a malformed peppered hash",
+			hasher:          rotated,
+			hashedPassword:  "$pepper$id=v1",
+			wantVerify:      false,
+			wantNeedsRehash: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := tt.hasher.VerifyPassword(context.Background(), "12345678", tt.hashedPassword)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVerify, matches)
+			assert.Equal(t, tt.wantNeedsRehash, tt.hasher.NeedsRehash(tt.hashedPassword))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+var (
+	ErrInvalidFileName    = errors.New("invalid migration file name")
+	ErrDuplicateMigration = errors.New("duplicated migration")
+	ErrMissingUp          = errors.New("migration without an up script")
+	ErrMissingDown        = errors.New("migration without a down script")
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Embedded returns the migrations shipped with the binary
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(sub)
+}
+
+// Load reads the migrations from the root of fsys, every version needs a
+// NNNN_name.up.sql and a NNNN_name.down.sql script. The checksum covers the up
+// script, the one recorded when the migration is applied
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFileName, entry.Name())
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFileName, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("%w: version %d", ErrDuplicateMigration, version)
+		}
+
+		switch matches[3] {
+		case "up":
+			if migration.Up != "" {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateMigration, entry.Name())
+			}
+			migration.Up = string(content)
+			migration.Checksum = checksum(content)
+		case "down":
+			if migration.Down != "" {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateMigration, entry.Name())
+			}
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: version %d", ErrMissingUp, migration.Version)
+		}
+
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: version %d", ErrMissingDown, migration.Version)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		fsys         fstest.MapFS
+		wantVersions []int64
+		wantErr      error
+	}{
+		{
+			name: "Load the migrations ordered by version",
+			fsys: fstest.MapFS{
+				"0010_second.up.sql":   {Data: []byte("SELECT 2;")},
+				"0010_second.down.sql": {Data: []byte("SELECT -2;")},
+				"0002_first.up.sql":    {Data: []byte("SELECT 1;")},
+				"0002_first.down.sql":  {Data: []byte("SELECT -1;")},
+				"README.md":            {Data: []byte("ignored")},
+			},
+			wantVersions: []int64{2, 10},
+		},
+		{
+			name: "Do not load a file with an invalid name",
+			fsys: fstest.MapFS{
+				"first.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: ErrInvalidFileName,
+		},
+		{
+			name: "Do not load a version with two names",
+			fsys: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_other.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_first.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			wantErr: ErrDuplicateMigration,
+		},
+		{
+			name: "Do not load a migration without its up script",
+			fsys: fstest.MapFS{
+				"0001_first.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			wantErr: ErrMissingUp,
+		},
+		{
+			name: "Do not load a migration without its down script",
+			fsys: fstest.MapFS{
+				"0001_first.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: ErrMissingDown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := Load(tt.fsys)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			versions := []int64{}
+			for _, migration := range got {
+				versions = append(versions, migration.Version)
+				assert.Len(t, migration.Checksum, 64)
+			}
+
+			assert.Equal(t, tt.wantVersions, versions)
+		})
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	// Act
+	got, err := Embedded()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got)
+
+	for i, migration := range got {
+		assert.Equal(t, int64(i+1), migration.Version, "migrations must not skip versions")
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
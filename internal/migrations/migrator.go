@@ -0,0 +1,307 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers/interfaces"
+)
+
+const (
+	// LOCK_ID identifies the advisory lock held while migrating, so concurrent
+	// deploys wait for each other instead of applying the same script twice
+	LOCK_ID int64 = 4731902365
+
+	COMMAND_UP     = "up"
+	COMMAND_DOWN   = "down"
+	COMMAND_STATUS = "status"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration checksum does not match")
+	ErrUnknownMigration = errors.New("applied migration is unknown")
+	ErrUnknownCommand   = errors.New("unknown migration command")
+	ErrInvalidSteps     = errors.New("steps must be greater than zero")
+)
+
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+type Request struct {
+	Command string `json:"command"`
+	Steps   int    `json:"steps"`
+}
+
+type Response struct {
+	Command    string   `json:"command"`
+	Migrations []Status `json:"migrations"`
+}
+
+type appliedMigration struct {
+	version   int64
+	checksum  string
+	appliedAt time.Time
+}
+
+type Migrator struct {
+	conn         *sql.DB
+	timeProvider interfaces.TimeProvider
+	migrations   []Migration
+}
+
+func NewMigrator(conn *sql.DB, timeProvider interfaces.TimeProvider, migrations []Migration) Migrator {
+	return Migrator{
+		conn:         conn,
+		timeProvider: timeProvider,
+		migrations:   migrations,
+	}
+}
+
+// Run executes a command coming from the command line or from a Lambda event,
+// a missing command applies the pending migrations
+func (m Migrator) Run(ctx context.Context, request Request) (Response, error) {
+	response := Response{Command: request.Command, Migrations: []Status{}}
+
+	switch request.Command {
+	case "", COMMAND_UP:
+		response.Command = COMMAND_UP
+
+		applied, err := m.Up(ctx)
+		for _, migration := range applied {
+			response.Migrations = append(response.Migrations, Status{Version: migration.Version, Name: migration.Name, Applied: true})
+		}
+
+		return response, err
+	case COMMAND_DOWN:
+		steps := request.Steps
+		if steps == 0 {
+			steps = 1
+		}
+
+		reverted, err := m.Down(ctx, steps)
+		for _, migration := range reverted {
+			response.Migrations = append(response.Migrations, Status{Version: migration.Version, Name: migration.Name})
+		}
+
+		return response, err
+	case COMMAND_STATUS:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return response, err
+		}
+
+		response.Migrations = statuses
+
+		return response, nil
+	}
+
+	return response, fmt.Errorf("%w: %q", ErrUnknownCommand, request.Command)
+}
+
+// Up applies every pending migration in version order, each one in its own
+// transaction, returning the ones applied before any failure
+func (m Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := []Migration{}
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			slog.Info("applying migration", "version", migration.Version, "name", migration.Name)
+
+			err := m.inTransaction(ctx, conn, migration.Up,
+				"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4);",
+				migration.Version,
+				migration.Name,
+				migration.Checksum,
+				m.timeProvider.GetTime())
+			if err != nil {
+				return fmt.Errorf("error applying migration %d: %w", migration.Version, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last applied migrations, newest first
+func (m Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, ErrInvalidSteps
+	}
+
+	reverted := []Migration{}
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			slog.Info("reverting migration", "version", migration.Version, "name", migration.Name)
+
+			err := m.inTransaction(ctx, conn, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = $1;",
+				migration.Version)
+			if err != nil {
+				return fmt.Errorf("error reverting migration %d: %w", migration.Version, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status only reads, so it neither waits for a running migration nor creates
+// the schema_migrations table, a database without it has every migration pending
+func (m Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.conn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL;").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("error checking the schema_migrations table: %w", err)
+	}
+
+	done := map[int64]appliedMigration{}
+	if exists {
+		if done, err = m.verify(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := []Status{}
+
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+
+		if applied, ok := done[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &applied.appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the advisory lock, advisory
+// locks belong to the session so every statement must use that connection
+func (m Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1);", LOCK_ID); err != nil {
+		return fmt.Errorf("error acquiring the migration lock: %w", err)
+	}
+
+	defer func() {
+		// the caller's context may be done by now, the lock must still be released
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1);", LOCK_ID); err != nil {
+			slog.Error("error releasing the migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name varchar(255) NOT NULL, checksum char(64) NOT NULL, applied_at TIMESTAMP NOT NULL);"); err != nil {
+		return fmt.Errorf("error creating the schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// verify loads the applied migrations, refusing to go on when one of them is
+// no longer known or its script changed after being applied
+func (m Migrator) verify(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT m.version, m.checksum, m.applied_at FROM schema_migrations m ORDER BY m.version;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int64]appliedMigration{}
+
+	for rows.Next() {
+		var applied appliedMigration
+		if err := rows.Scan(&applied.version, &applied.checksum, &applied.appliedAt); err != nil {
+			return nil, err
+		}
+
+		done[applied.version] = applied
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, applied := range done {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d", ErrUnknownMigration, version)
+		}
+
+		if migration.Checksum != applied.checksum {
+			return nil, fmt.Errorf("%w: version %d", ErrChecksumMismatch, version)
+		}
+	}
+
+	return done, nil
+}
+
+func (m Migrator) inTransaction(ctx context.Context, conn *sql.Conn, script string, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,279 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers/interfaces/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2024, 4, 13, 23, 37, 11, 0, time.UTC)
+
+func newTestMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "first", Up: "CREATE TABLE first (id int);", Down: "DROP TABLE first;", Checksum: checksum([]byte("CREATE TABLE first (id int);"))},
+		{Version: 2, Name: "second", Up: "CREATE TABLE second (id int);", Down: "DROP TABLE second;", Checksum: checksum([]byte("CREATE TABLE second (id int);"))},
+	}
+}
+
+func newTestMigrator(t *testing.T) (Migrator, sqlmock.Sqlmock, *mocks.MockTimeProvider) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	timeProviderMock := mocks.NewMockTimeProvider(t)
+
+	return NewMigrator(db, timeProviderMock, newTestMigrations()), mock, timeProviderMock
+}
+
+func expectLock(mock sqlmock.Sqlmock, applied *sqlmock.Rows) {
+	mock.ExpectExec("SELECT pg_advisory_lock").
+		WithArgs(LOCK_ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectQuery("SELECT (.+) FROM schema_migrations").
+		WillReturnRows(applied)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("SELECT pg_advisory_unlock").
+		WithArgs(LOCK_ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"version", "checksum", "applied_at"})
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("Should apply only the pending migrations", func(t *testing.T) {
+		// Arrange
+		migrator, mock, timeProviderMock := newTestMigrator(t)
+
+		timeProviderMock.On("GetTime").
+			Return(now).
+			Once()
+
+		expectLock(mock, appliedRows().AddRow(1, newTestMigrations()[0].Checksum, now))
+
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE second").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").
+			WithArgs(int64(2), "second", newTestMigrations()[1].Checksum, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		expectUnlock(mock)
+
+		// Act
+		got, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int64(2), got[0].Version)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should roll back a failed migration and keep the lock released", func(t *testing.T) {
+		// Arrange
+		migrator, mock, timeProviderMock := newTestMigrator(t)
+
+		timeProviderMock.On("GetTime").
+			Return(now).
+			Once()
+
+		expectLock(mock, appliedRows().AddRow(1, newTestMigrations()[0].Checksum, now))
+
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE second").
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		expectUnlock(mock)
+
+		// Act
+		got, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Empty(t, got)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should refuse to migrate when an applied script changed", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		expectLock(mock, appliedRows().AddRow(1, checksum([]byte("changed")), now))
+		expectUnlock(mock)
+
+		// Act
+		_, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should refuse to migrate when an applied migration is unknown", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		expectLock(mock, appliedRows().AddRow(3, checksum([]byte("third")), now))
+		expectUnlock(mock)
+
+		// Act
+		_, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownMigration)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("Should revert the newest applied migration", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		expectLock(mock, appliedRows().
+			AddRow(1, newTestMigrations()[0].Checksum, now).
+			AddRow(2, newTestMigrations()[1].Checksum, now))
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DROP TABLE second").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM schema_migrations").
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		expectUnlock(mock)
+
+		// Act
+		got, err := migrator.Down(context.Background(), 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int64(2), got[0].Version)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should stop before locking when the context is done", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		_, err := migrator.Down(ctx, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should not revert without steps", func(t *testing.T) {
+		// Arrange
+		migrator, _, _ := newTestMigrator(t)
+
+		// Act
+		_, err := migrator.Down(context.Background(), 0)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidSteps)
+	})
+}
+
+func TestMigrator_Run(t *testing.T) {
+	t.Run("Should report the status of every migration", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		mock.ExpectQuery("SELECT to_regclass").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery("SELECT (.+) FROM schema_migrations").
+			WillReturnRows(appliedRows().AddRow(1, newTestMigrations()[0].Checksum, now))
+
+		// Act
+		got, err := migrator.Run(context.Background(), Request{Command: COMMAND_STATUS})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, COMMAND_STATUS, got.Command)
+		assert.Equal(t, []Status{
+			{Version: 1, Name: "first", Applied: true, AppliedAt: &now},
+			{Version: 2, Name: "second"},
+		}, got.Migrations)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should report every migration as pending before the first migration", func(t *testing.T) {
+		// Arrange
+		migrator, mock, _ := newTestMigrator(t)
+
+		mock.ExpectQuery("SELECT to_regclass").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		// Act
+		got, err := migrator.Run(context.Background(), Request{Command: COMMAND_STATUS})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []Status{
+			{Version: 1, Name: "first"},
+			{Version: 2, Name: "second"},
+		}, got.Migrations)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Should return an error for an unknown command", func(t *testing.T) {
+		// Arrange
+		migrator, _, _ := newTestMigrator(t)
+
+		// Act
+		_, err := migrator.Run(context.Background(), Request{Command: "redo"})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+	})
+}
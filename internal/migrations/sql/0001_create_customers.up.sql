@@ -7,4 +7,4 @@ CREATE TABLE IF NOT EXISTS customers (
     created_at TIMESTAMP,
     updated_at TIMESTAMP,
     PRIMARY KEY (id)
-);
\ No newline at end of file
+);
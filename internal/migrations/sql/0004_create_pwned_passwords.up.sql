@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS pwned_passwords (
+    prefix char(5),
+    suffix char(35),
+    count int NOT NULL,
+    PRIMARY KEY (prefix, suffix)
+);
@@ -0,0 +1,9 @@
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS fiscal_region smallint;
+
+-- The customers registered before the column existed get the region of their
+-- CPF, encoded by its ninth digit, stored either formatted or as plain digits
+UPDATE customers c
+   SET fiscal_region = substr(regexp_replace(c.document_id, '[^0-9]', '', 'g'), 9, 1)::smallint
+ WHERE c.document_type = 1
+   AND c.fiscal_region IS NULL
+   AND length(regexp_replace(c.document_id, '[^0-9]', '', 'g')) = 11;
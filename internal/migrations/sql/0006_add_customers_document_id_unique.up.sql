@@ -0,0 +1,25 @@
+-- Registrations used to check the document before inserting it, so concurrent
+-- requests may have stored the same document twice. The index can not be built
+-- over those rows: the migration stops and lists the customers involved, keep
+-- one row of each group (e.g. the oldest by created_at), delete or anonymize
+-- the others and run the migration again.
+DO $$
+DECLARE
+    duplicates text;
+BEGIN
+    SELECT string_agg(format('document_type %s: %s', d.document_type, d.ids), '; ')
+      INTO duplicates
+      FROM (
+        SELECT c.document_type, string_agg(c.id, ', ' ORDER BY c.created_at, c.id) AS ids
+          FROM customers c
+         WHERE c.document_id IS NOT NULL
+         GROUP BY c.document_type, c.document_id
+        HAVING count(*) > 1
+      ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'customers share a document, keep one customer of each group and remove the others: %', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customers_document_id ON customers (document_type, document_id);
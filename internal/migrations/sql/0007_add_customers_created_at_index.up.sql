@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_customers_created_at ON customers (created_at, id);
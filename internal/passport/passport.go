@@ -0,0 +1,107 @@
+package passport
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+)
+
+const (
+	MIN_LENGTH     = 6
+	MAX_LENGTH     = 9
+	COUNTRY_LENGTH = 3
+
+	// COUNTRY_SEPARATOR joins the issuing country to the number, the numbers
+	// are only unique within a country so both are kept as the document value
+	COUNTRY_SEPARATOR = ":"
+)
+
+var (
+	ErrInvalidPassport = errors.New("invalid passport")
+)
+
+// Passport holds the issuing country and the number of a foreign customer
+// passport as COUNTRY:NUMBER, the country is the ISO 3166-1 alpha-3 code and,
+// as the number formats vary between countries, only the ICAO characters and
+// length of the number are checked
+type Passport string
+
+func NewPassport(s string) Passport {
+	return Passport(Clean(s))
+}
+
+// NewPassportOf builds the passport of a number issued by the given country
+func NewPassportOf(country string, number string) Passport {
+	return NewPassport(country + COUNTRY_SEPARATOR + number)
+}
+
+func (p *Passport) IsValid() bool {
+	country, number, found := strings.Cut(string(*p), COUNTRY_SEPARATOR)
+	if !found || len(country) != COUNTRY_LENGTH {
+		return false
+	}
+
+	for i := 0; i < len(country); i++ {
+		if country[i] < 'A' || country[i] > 'Z' {
+			return false
+		}
+	}
+
+	if len(number) < MIN_LENGTH || len(number) > MAX_LENGTH {
+		return false
+	}
+
+	for i := 0; i < len(number); i++ {
+		c := number[i]
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Passport) Validate() error {
+	if !p.IsValid() {
+		return ErrInvalidPassport
+	}
+
+	return nil
+}
+
+func (p *Passport) Clean() string {
+	return string(*p)
+}
+
+func (p *Passport) Format() string {
+	return string(*p)
+}
+
+func (p *Passport) Type() int {
+	return entities.DOCUMENT_TYPE_PASSPORT
+}
+
+// Mask keeps the issuing country visible, only the number is masked
+func (p *Passport) Mask() string {
+	country, number, found := strings.Cut(string(*p), COUNTRY_SEPARATOR)
+	if !found {
+		country, number = "", country
+	} else {
+		country += COUNTRY_SEPARATOR
+	}
+
+	if len(number) < 4 {
+		return country + strings.Repeat("*", len(number))
+	}
+
+	return country + number[:2] + strings.Repeat("*", len(number)-4) + number[len(number)-2:]
+}
+
+func Clean(passport string) string {
+	charsToRemove := []string{"-", " "}
+	for _, char := range charsToRemove {
+		passport = strings.ReplaceAll(passport, char, "")
+	}
+	return strings.ToUpper(passport)
+}
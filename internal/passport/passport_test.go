@@ -0,0 +1,113 @@
+package passport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPassport(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		wantString     string
+		wantValidation bool
+	}{
+		{
+			name:           "Create a new passport with a valid value",
+			value:          "usa:x1234567",
+			wantString:     "USA:X1234567",
+			wantValidation: true,
+		},
+		{
+			name:           "Create a new passport ignoring separators",
+			value:          "USA:AB 123-456",
+			wantString:     "USA:AB123456",
+			wantValidation: true,
+		},
+		{
+			name:           "Create a new passport with a short value",
+			value:          "USA:AB123",
+			wantString:     "USA:AB123",
+			wantValidation: false,
+		},
+		{
+			name:           "Create a new passport with a long value",
+			value:          "USA:AB1234567",
+			wantString:     "USA:AB1234567",
+			wantValidation: true,
+		},
+		{
+			name:           "Create a new passport with a too long value",
+			value:          "USA:AB12345678",
+			wantString:     "USA:AB12345678",
+			wantValidation: false,
+		},
+		{
+			name:           "Create a new passport with invalid characters",
+			value:          "USA:AB1234/5",
+			wantString:     "USA:AB1234/5",
+			wantValidation: false,
+		},
+		{
+			name:           "Create a new passport without the issuing country",
+			value:          "X1234567",
+			wantString:     "X1234567",
+			wantValidation: false,
+		},
+		{
+			name:           "Create a new passport with an invalid issuing country",
+			value:          "U5:X1234567",
+			wantString:     "U5:X1234567",
+			wantValidation: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := NewPassport(tt.value)
+
+			// Assert
+			assert.Equal(t, tt.wantValidation, got.IsValid())
+			assert.Equal(t, tt.wantString, got.Format())
+		})
+	}
+}
+
+func TestPassport_Mask(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Passport
+		want string
+	}{
+		{
+			name: "Mask a valid passport keeping the issuing country",
+			p:    NewPassport("USA:X1234567"),
+			want: "USA:X1****67",
+		},
+		{
+			name: "Mask a passport without the issuing country",
+			p:    NewPassport("X1234567"),
+			want: "X1****67",
+		},
+		{
+			name: "Mask a short value entirely",
+			p:    NewPassport("USA:X12"),
+			want: "USA:***",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.p.Mask())
+		})
+	}
+}
+
+func TestNewPassportOf(t *testing.T) {
+	// Act
+	got := NewPassportOf("usa", "x1234567")
+
+	// Assert
+	assert.True(t, got.IsValid())
+	assert.Equal(t, "USA:X1234567", got.Format())
+}
@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	DEFAULT_MIN_LENGTH = 8
+
+	// bcrypt ignores everything after the 72th byte of the password
+	DEFAULT_MAX_LENGTH = 72
+
+	DEFAULT_REJECT_DOCUMENT = true
+	DEFAULT_MAX_REPEATED    = 3
+	DEFAULT_MAX_SEQUENTIAL  = 4
+)
+
+// Config describes the password rules, zero values disable the rule
+type Config struct {
+	MinLength      int
+	MaxLength      int
+	RequireLower   bool
+	RequireUpper   bool
+	RequireDigit   bool
+	RequireSymbol  bool
+	RejectDocument bool
+	MaxRepeated    int
+	MaxSequential  int
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		MinLength:      DEFAULT_MIN_LENGTH,
+		MaxLength:      DEFAULT_MAX_LENGTH,
+		RejectDocument: DEFAULT_REJECT_DOCUMENT,
+		MaxRepeated:    DEFAULT_MAX_REPEATED,
+		MaxSequential:  DEFAULT_MAX_SEQUENTIAL,
+	}
+}
+
+// NewConfigFromEnv loads the rules from the PASSWORD_* variables, keeping the
+// defaults for the ones not informed. A rule enabled by default is disabled
+// with 0 or false
+func NewConfigFromEnv() (Config, error) {
+	config := NewDefaultConfig()
+
+	ints := map[string]*int{
+		"PASSWORD_MIN_LENGTH":     &config.MinLength,
+		"PASSWORD_MAX_LENGTH":     &config.MaxLength,
+		"PASSWORD_MAX_REPEATED":   &config.MaxRepeated,
+		"PASSWORD_MAX_SEQUENTIAL": &config.MaxSequential,
+	}
+
+	for name, target := range ints {
+		raw := os.Getenv(name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return Config{}, fmt.Errorf("invalid %s %q", name, raw)
+		}
+
+		*target = value
+	}
+
+	bools := map[string]*bool{
+		"PASSWORD_REQUIRE_LOWER":   &config.RequireLower,
+		"PASSWORD_REQUIRE_UPPER":   &config.RequireUpper,
+		"PASSWORD_REQUIRE_DIGIT":   &config.RequireDigit,
+		"PASSWORD_REQUIRE_SYMBOL":  &config.RequireSymbol,
+		"PASSWORD_REJECT_DOCUMENT": &config.RejectDocument,
+	}
+
+	for name, target := range bools {
+		raw := os.Getenv(name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s %q", name, raw)
+		}
+
+		*target = value
+	}
+
+	return config, nil
+}
@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("Should return the default configuration", func(t *testing.T) {
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, NewDefaultConfig(), got)
+	})
+
+	t.Run("Should load the rules", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PASSWORD_MIN_LENGTH", "12")
+		t.Setenv("PASSWORD_MAX_LENGTH", "64")
+		t.Setenv("PASSWORD_REQUIRE_LOWER", "true")
+		t.Setenv("PASSWORD_REQUIRE_UPPER", "true")
+		t.Setenv("PASSWORD_REQUIRE_DIGIT", "true")
+		t.Setenv("PASSWORD_REQUIRE_SYMBOL", "true")
+		t.Setenv("PASSWORD_REJECT_DOCUMENT", "true")
+		t.Setenv("PASSWORD_MAX_REPEATED", "3")
+		t.Setenv("PASSWORD_MAX_SEQUENTIAL", "4")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, Config{
+			MinLength:      12,
+			MaxLength:      64,
+			RequireLower:   true,
+			RequireUpper:   true,
+			RequireDigit:   true,
+			RequireSymbol:  true,
+			RejectDocument: true,
+			MaxRepeated:    3,
+			MaxSequential:  4,
+		}, got)
+	})
+
+	t.Run("Should disable the rules enabled by default", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PASSWORD_REJECT_DOCUMENT", "false")
+		t.Setenv("PASSWORD_MAX_REPEATED", "0")
+		t.Setenv("PASSWORD_MAX_SEQUENTIAL", "0")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, got.RejectDocument)
+		assert.Zero(t, got.MaxRepeated)
+		assert.Zero(t, got.MaxSequential)
+	})
+
+	t.Run("Should return an error when a value is invalid", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PASSWORD_REQUIRE_UPPER", "sometimes")
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entities "github.com/jfelipearaujo-org/lambda-register/internal/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPolicy is an autogenerated mock type for the Policy type
+type MockPolicy struct {
+	mock.Mock
+}
+
+// Validate provides a mock function with given fields: ctx, password, document
+func (_m *MockPolicy) Validate(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	ret := _m.Called(ctx, password, document)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 []entities.PolicyViolation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []entities.PolicyViolation); ok {
+		r0 = rf(ctx, password, document)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.PolicyViolation)
+		}
+	}
+
+	return r0
+}
+
+// NewMockPolicy creates a new instance of MockPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPolicy {
+	mock := &MockPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
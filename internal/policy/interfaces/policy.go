@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+)
+
+type Policy interface {
+	Validate(ctx context.Context, password string, document string) []entities.PolicyViolation
+}
+
+type Rule interface {
+	Check(ctx context.Context, password string, document string) []entities.PolicyViolation
+}
@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/policy/interfaces"
+)
+
+var (
+	ErrInvalidLengthRange = errors.New("the minimum password length is greater than the maximum")
+)
+
+type Policy struct {
+	rules []interfaces.Rule
+}
+
+// NewPolicy builds the rules described by the config followed by the extra
+// rules informed
+func NewPolicy(config Config, rules ...interfaces.Rule) (Policy, error) {
+	if config.MaxLength > 0 && config.MinLength > config.MaxLength {
+		return Policy{}, ErrInvalidLengthRange
+	}
+
+	all := []interfaces.Rule{
+		NewLengthRule(config.MinLength, config.MaxLength),
+	}
+
+	if config.RequireLower || config.RequireUpper || config.RequireDigit || config.RequireSymbol {
+		all = append(all, NewCharacterClassRule(config.RequireLower, config.RequireUpper, config.RequireDigit, config.RequireSymbol))
+	}
+
+	if config.RejectDocument {
+		all = append(all, NewDocumentRule())
+	}
+
+	if config.MaxRepeated > 0 {
+		all = append(all, NewRepeatedRule(config.MaxRepeated))
+	}
+
+	if config.MaxSequential > 0 {
+		all = append(all, NewSequentialRule(config.MaxSequential))
+	}
+
+	return Policy{
+		rules: append(all, rules...),
+	}, nil
+}
+
+// Validate runs every rule, returning all the violations found
+func (p Policy) Validate(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	var violations []entities.PolicyViolation
+
+	for _, rule := range p.rules {
+		violations = append(violations, rule.Check(ctx, password, document)...)
+	}
+
+	return violations
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type ruleFunc func(password string, document string) []entities.PolicyViolation
+
+func (f ruleFunc) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	return f(password, document)
+}
+
+func TestNewPolicy(t *testing.T) {
+	t.Run("Should return an error when the length range is invalid", func(t *testing.T) {
+		// Arrange
+		config := NewDefaultConfig()
+		config.MinLength = 80
+
+		// Act
+		_, err := NewPolicy(config)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidLengthRange)
+	})
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Run("Should accept the password with the default config", func(t *testing.T) {
+		// Arrange
+		policy, err := NewPolicy(NewDefaultConfig())
+		assert.NoError(t, err)
+
+		// Act
+		got := policy.Validate(context.Background(), "fastfood-2024", "218.486.310-65")
+
+		// Assert
+		assert.Empty(t, got)
+	})
+
+	t.Run("Should reject the document, repeated and sequential runs by default", func(t *testing.T) {
+		// Arrange
+		policy, err := NewPolicy(NewDefaultConfig())
+		assert.NoError(t, err)
+
+		// Act
+		withDocument := policy.Validate(context.Background(), "x21848631065", "218.486.310-65")
+		withRepeated := policy.Validate(context.Background(), "fastfooood-2024", "")
+		withSequential := policy.Validate(context.Background(), "fastfood-12345", "")
+
+		// Assert
+		assert.Equal(t, []entities.PolicyViolation{
+			entities.NewPolicyViolation(RULE_DOCUMENT, "password must not contain the document"),
+		}, withDocument)
+		assert.Equal(t, RULE_REPEATED, withRepeated[0].Rule)
+		assert.Equal(t, RULE_SEQUENTIAL, withSequential[0].Rule)
+	})
+
+	t.Run("Should return every failed rule", func(t *testing.T) {
+		// Arrange
+		policy, err := NewPolicy(Config{
+			MinLength:      12,
+			MaxLength:      DEFAULT_MAX_LENGTH,
+			RequireUpper:   true,
+			RequireSymbol:  true,
+			RejectDocument: true,
+			MaxRepeated:    3,
+			MaxSequential:  3,
+		})
+		assert.NoError(t, err)
+
+		// Act
+		got := policy.Validate(context.Background(), "21848631065", "218.486.310-65")
+
+		// Assert
+		assert.Equal(t, []entities.PolicyViolation{
+			entities.NewPolicyViolation(RULE_MIN_LENGTH, "password must have at least 12 characters"),
+			entities.NewPolicyViolation(RULE_UPPERCASE, "password must have an uppercase letter"),
+			entities.NewPolicyViolation(RULE_SYMBOL, "password must have a symbol"),
+			entities.NewPolicyViolation(RULE_DOCUMENT, "password must not contain the document"),
+		}, got)
+	})
+
+	t.Run("Should run the extra rules", func(t *testing.T) {
+		// Arrange
+		extra := ruleFunc(func(password string, document string) []entities.PolicyViolation {
+			return []entities.PolicyViolation{
+				entities.NewPolicyViolation("custom", "custom rule"),
+			}
+		})
+
+		policy, err := NewPolicy(NewDefaultConfig(), extra)
+		assert.NoError(t, err)
+
+		// Act
+		got := policy.Validate(context.Background(), "fastfood-2024", "")
+
+		// Assert
+		assert.Equal(t, []entities.PolicyViolation{
+			entities.NewPolicyViolation("custom", "custom rule"),
+		}, got)
+	})
+}
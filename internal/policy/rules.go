@@ -0,0 +1,226 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+)
+
+const (
+	RULE_MIN_LENGTH = "min_length"
+	RULE_MAX_LENGTH = "max_length"
+	RULE_LOWERCASE  = "lowercase"
+	RULE_UPPERCASE  = "uppercase"
+	RULE_DIGIT      = "digit"
+	RULE_SYMBOL     = "symbol"
+	RULE_DOCUMENT   = "document"
+	RULE_REPEATED   = "repeated"
+	RULE_SEQUENTIAL = "sequential"
+)
+
+type LengthRule struct {
+	min int
+	max int
+}
+
+func NewLengthRule(min int, max int) LengthRule {
+	return LengthRule{
+		min: min,
+		max: max,
+	}
+}
+
+// Check counts characters for the minimum and bytes for the maximum, since the
+// maximum exists because of the hash algorithm limits
+func (r LengthRule) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	var violations []entities.PolicyViolation
+
+	if r.min > 0 && utf8.RuneCountInString(password) < r.min {
+		violations = append(violations, entities.NewPolicyViolation(RULE_MIN_LENGTH,
+			fmt.Sprintf("password must have at least %d characters", r.min)))
+	}
+
+	if r.max > 0 && len(password) > r.max {
+		violations = append(violations, entities.NewPolicyViolation(RULE_MAX_LENGTH,
+			fmt.Sprintf("password must have at most %d bytes", r.max)))
+	}
+
+	return violations
+}
+
+type CharacterClassRule struct {
+	lower  bool
+	upper  bool
+	digit  bool
+	symbol bool
+}
+
+func NewCharacterClassRule(lower bool, upper bool, digit bool, symbol bool) CharacterClassRule {
+	return CharacterClassRule{
+		lower:  lower,
+		upper:  upper,
+		digit:  digit,
+		symbol: symbol,
+	}
+}
+
+func (r CharacterClassRule) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+
+	for _, char := range password {
+		switch {
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char) || unicode.IsSpace(char):
+			hasSymbol = true
+		}
+	}
+
+	var violations []entities.PolicyViolation
+
+	if r.lower && !hasLower {
+		violations = append(violations, entities.NewPolicyViolation(RULE_LOWERCASE, "password must have a lowercase letter"))
+	}
+
+	if r.upper && !hasUpper {
+		violations = append(violations, entities.NewPolicyViolation(RULE_UPPERCASE, "password must have an uppercase letter"))
+	}
+
+	if r.digit && !hasDigit {
+		violations = append(violations, entities.NewPolicyViolation(RULE_DIGIT, "password must have a digit"))
+	}
+
+	if r.symbol && !hasSymbol {
+		violations = append(violations, entities.NewPolicyViolation(RULE_SYMBOL, "password must have a symbol"))
+	}
+
+	return violations
+}
+
+type DocumentRule struct {
+}
+
+func NewDocumentRule() DocumentRule {
+	return DocumentRule{}
+}
+
+// Check rejects passwords carrying the document characters, even when they
+// are mixed with separators like in 123.456.789-09 or 12.ABC.345/01DE-35
+func (r DocumentRule) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	characters := onlyAlphanumeric(document)
+	if characters == "" {
+		return nil
+	}
+
+	if !strings.Contains(onlyAlphanumeric(password), characters) {
+		return nil
+	}
+
+	return []entities.PolicyViolation{
+		entities.NewPolicyViolation(RULE_DOCUMENT, "password must not contain the document"),
+	}
+}
+
+type RepeatedRule struct {
+	max int
+}
+
+func NewRepeatedRule(max int) RepeatedRule {
+	return RepeatedRule{
+		max: max,
+	}
+}
+
+func (r RepeatedRule) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	run := 0
+	var previous rune
+
+	for i, char := range []rune(password) {
+		if i > 0 && char == previous {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > r.max {
+			return []entities.PolicyViolation{
+				entities.NewPolicyViolation(RULE_REPEATED,
+					fmt.Sprintf("password must not repeat a character more than %d times in a row", r.max)),
+			}
+		}
+
+		previous = char
+	}
+
+	return nil
+}
+
+type SequentialRule struct {
+	max int
+}
+
+func NewSequentialRule(max int) SequentialRule {
+	return SequentialRule{
+		max: max,
+	}
+}
+
+// Check looks for ascending or descending runs of letters or digits, like
+// 1234, abcd or 4321, ignoring the letter case
+func (r SequentialRule) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	ascending, descending := 1, 1
+	chars := []rune(strings.ToLower(password))
+
+	for i := 1; i < len(chars); i++ {
+		previous, current := chars[i-1], chars[i]
+
+		if !isSequenceable(previous) || !isSequenceable(current) {
+			ascending, descending = 1, 1
+			continue
+		}
+
+		switch current - previous {
+		case 1:
+			ascending++
+			descending = 1
+		case -1:
+			descending++
+			ascending = 1
+		default:
+			ascending, descending = 1, 1
+		}
+
+		if ascending > r.max || descending > r.max {
+			return []entities.PolicyViolation{
+				entities.NewPolicyViolation(RULE_SEQUENTIAL,
+					fmt.Sprintf("password must not have sequences longer than %d characters", r.max)),
+			}
+		}
+	}
+
+	return nil
+}
+
+func isSequenceable(char rune) bool {
+	return (char >= '0' && char <= '9') || (char >= 'a' && char <= 'z')
+}
+
+func onlyAlphanumeric(value string) string {
+	var builder strings.Builder
+
+	for _, char := range strings.ToUpper(value) {
+		if (char >= '0' && char <= '9') || (char >= 'A' && char <= 'Z') {
+			builder.WriteRune(char)
+		}
+	}
+
+	return builder.String()
+}
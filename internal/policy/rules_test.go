@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/policy/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func rulesOf(t *testing.T, rule interfaces.Rule, password string, document string) []string {
+	t.Helper()
+
+	var rules []string
+	for _, violation := range rule.Check(context.Background(), password, document) {
+		rules = append(rules, violation.Rule)
+	}
+
+	return rules
+}
+
+func TestLengthRule_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{
+			name:     "Accept a password within the limits",
+			password: "12345678",
+			want:     nil,
+		},
+		{
+			name:     "Reject a short password",
+			password: "1234567",
+			want:     []string{RULE_MIN_LENGTH},
+		},
+		{
+			name:     "Count characters instead of bytes for the minimum",
+			password: "çãéõüíàâ",
+			want:     nil,
+		},
+		{
+			name:     "Reject a password longer than bcrypt accepts",
+			password: strings.Repeat("a", 73),
+			want:     []string{RULE_MAX_LENGTH},
+		},
+		{
+			name:     "Count bytes for the maximum",
+			password: strings.Repeat("ç", 37),
+			want:     []string{RULE_MAX_LENGTH},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rulesOf(t, NewLengthRule(DEFAULT_MIN_LENGTH, DEFAULT_MAX_LENGTH), tt.password, ""))
+		})
+	}
+}
+
+func TestCharacterClassRule_Check(t *testing.T) {
+	rule := NewCharacterClassRule(true, true, true, true)
+
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{
+			name:     "Accept a password with every class",
+			password: "aB3$",
+			want:     nil,
+		},
+		{
+			name:     "Report every missing class",
+			password: "abc",
+			want:     []string{RULE_UPPERCASE, RULE_DIGIT, RULE_SYMBOL},
+		},
+		{
+			name:     "Accept unicode letters",
+			password: "çÇ3 ",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rulesOf(t, rule, tt.password, ""))
+		})
+	}
+}
+
+func TestDocumentRule_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		document string
+		want     []string
+	}{
+		{
+			name:     "Accept a password without the document",
+			password: "correct horse",
+			document: "218.486.310-65",
+			want:     nil,
+		},
+		{
+			name:     "Reject a password with the document digits",
+			password: "pass21848631065",
+			document: "218.486.310-65",
+			want:     []string{RULE_DOCUMENT},
+		},
+		{
+			name:     "Reject a password with the formatted document",
+			password: "x218.486.310-65x",
+			document: "218.486.310-65",
+			want:     []string{RULE_DOCUMENT},
+		},
+		{
+			name:     "Reject a password with an alphanumeric CNPJ ignoring the case",
+			password: "my-12abc34501de35",
+			document: "12.ABC.345/01DE-35",
+			want:     []string{RULE_DOCUMENT},
+		},
+		{
+			name:     "Accept any password without document",
+			password: "21848631065",
+			document: "",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rulesOf(t, NewDocumentRule(), tt.password, tt.document))
+		})
+	}
+}
+
+func TestRepeatedRule_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{
+			name:     "Accept runs up to the limit",
+			password: "aaabbbccc",
+			want:     nil,
+		},
+		{
+			name:     "Reject runs above the limit",
+			password: "passs1111",
+			want:     []string{RULE_REPEATED},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rulesOf(t, NewRepeatedRule(3), tt.password, ""))
+		})
+	}
+}
+
+func TestSequentialRule_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{
+			name:     "Accept sequences up to the limit",
+			password: "abc-123-cba",
+			want:     nil,
+		},
+		{
+			name:     "Reject ascending digits",
+			password: "x1234x",
+			want:     []string{RULE_SEQUENTIAL},
+		},
+		{
+			name:     "Reject descending letters ignoring the case",
+			password: "xDcBax",
+			want:     []string{RULE_SEQUENTIAL},
+		},
+		{
+			name:     "Do not join sequences across separators",
+			password: "12-34-56",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rulesOf(t, NewSequentialRule(3), tt.password, ""))
+		})
+	}
+}
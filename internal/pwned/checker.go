@@ -0,0 +1,53 @@
+package pwned
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/pwned/interfaces"
+)
+
+const (
+	RULE_BREACHED = "breached"
+
+	PREFIX_LENGTH = 5
+)
+
+// Checker is a password policy rule rejecting passwords found in the
+// breached passwords corpus
+type Checker struct {
+	store    interfaces.Store
+	minCount int
+}
+
+func NewChecker(store interfaces.Store, minCount int) Checker {
+	return Checker{
+		store:    store,
+		minCount: minCount,
+	}
+}
+
+// Check fails open when the corpus can not be read, a broken corpus must not
+// block every registration
+func (c Checker) Check(ctx context.Context, password string, document string) []entities.PolicyViolation {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	count, err := c.store.CountPwnedPassword(ctx, hash[:PREFIX_LENGTH], hash[PREFIX_LENGTH:])
+	if err != nil {
+		slog.Error("error checking pwned passwords", "error", err)
+		return nil
+	}
+
+	if count < c.minCount {
+		return nil
+	}
+
+	return []entities.PolicyViolation{
+		entities.NewPolicyViolation(RULE_BREACHED, "password was found in a data breach"),
+	}
+}
@@ -0,0 +1,67 @@
+package pwned
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/pwned/interfaces/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		err      error
+		minCount int
+		want     []entities.PolicyViolation
+	}{
+		{
+			name:     "Reject a breached password",
+			count:    3861493,
+			minCount: 1,
+			want: []entities.PolicyViolation{
+				entities.NewPolicyViolation(RULE_BREACHED, "password was found in a data breach"),
+			},
+		},
+		{
+			name:     "Accept a password not found",
+			count:    0,
+			minCount: 1,
+			want:     nil,
+		},
+		{
+			name:     "Accept a password seen fewer times than the minimum",
+			count:    2,
+			minCount: 10,
+			want:     nil,
+		},
+		{
+			name:     "Accept the password when the store fails",
+			err:      errors.New("error"),
+			minCount: 1,
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := mocks.NewMockStore(t)
+			store.On("CountPwnedPassword", mock.Anything, "5BAA6", "1E4C9B93F3F0682250B6CF8331B7EE68FD8").
+				Return(tt.count, tt.err).
+				Once()
+
+			checker := NewChecker(store, tt.minCount)
+
+			// Act
+			got := checker.Check(context.Background(), "password", "")
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package pwned
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/pwned/interfaces"
+)
+
+const (
+	SOURCE_NONE     = ""
+	SOURCE_FILE     = "file"
+	SOURCE_DATABASE = "database"
+
+	DEFAULT_MIN_COUNT = 1
+)
+
+var (
+	ErrUnsupportedSource = errors.New("unsupported pwned passwords source")
+	ErrMissingDirectory  = errors.New("missing pwned passwords directory")
+)
+
+type Config struct {
+	Source   string
+	Dir      string
+	MinCount int
+}
+
+// NewConfigFromEnv loads the corpus location from PWNED_PASSWORDS_SOURCE and
+// PWNED_PASSWORDS_DIR, the check is disabled when no source is informed
+func NewConfigFromEnv() (Config, error) {
+	config := Config{
+		Source:   os.Getenv("PWNED_PASSWORDS_SOURCE"),
+		Dir:      os.Getenv("PWNED_PASSWORDS_DIR"),
+		MinCount: DEFAULT_MIN_COUNT,
+	}
+
+	if raw := os.Getenv("PWNED_PASSWORDS_MIN_COUNT"); raw != "" {
+		count, err := strconv.Atoi(raw)
+		if err != nil || count < 1 {
+			return Config{}, fmt.Errorf("invalid PWNED_PASSWORDS_MIN_COUNT %q", raw)
+		}
+		config.MinCount = count
+	}
+
+	return config, nil
+}
+
+// NewStore returns the store described by the config, or nil when the check
+// is disabled. The database store is the one informed
+func NewStore(config Config, db interfaces.Store) (interfaces.Store, error) {
+	switch config.Source {
+	case SOURCE_NONE:
+		return nil, nil
+	case SOURCE_FILE:
+		if config.Dir == "" {
+			return nil, ErrMissingDirectory
+		}
+		return NewFileStore(config.Dir), nil
+	case SOURCE_DATABASE:
+		return db, nil
+	}
+
+	return nil, ErrUnsupportedSource
+}
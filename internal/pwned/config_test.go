@@ -0,0 +1,72 @@
+package pwned
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/pwned/interfaces/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("Should load the configuration", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PWNED_PASSWORDS_SOURCE", SOURCE_FILE)
+		t.Setenv("PWNED_PASSWORDS_DIR", "/opt/pwned")
+		t.Setenv("PWNED_PASSWORDS_MIN_COUNT", "5")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, Config{Source: SOURCE_FILE, Dir: "/opt/pwned", MinCount: 5}, got)
+	})
+
+	t.Run("Should return an error when the minimum count is invalid", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PWNED_PASSWORDS_MIN_COUNT", "0")
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	db := mocks.NewMockStore(t)
+
+	t.Run("Should disable the check without source", func(t *testing.T) {
+		got, err := NewStore(Config{}, db)
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Should return the file store", func(t *testing.T) {
+		got, err := NewStore(Config{Source: SOURCE_FILE, Dir: "/opt/pwned"}, db)
+
+		assert.NoError(t, err)
+		assert.Equal(t, NewFileStore("/opt/pwned"), got)
+	})
+
+	t.Run("Should return an error when the directory is missing", func(t *testing.T) {
+		_, err := NewStore(Config{Source: SOURCE_FILE}, db)
+
+		assert.ErrorIs(t, err, ErrMissingDirectory)
+	})
+
+	t.Run("Should return the database store", func(t *testing.T) {
+		got, err := NewStore(Config{Source: SOURCE_DATABASE}, db)
+
+		assert.NoError(t, err)
+		assert.Equal(t, db, got)
+	})
+
+	t.Run("Should return an error for an unknown source", func(t *testing.T) {
+		_, err := NewStore(Config{Source: "s3"}, db)
+
+		assert.ErrorIs(t, err, ErrUnsupportedSource)
+	})
+}
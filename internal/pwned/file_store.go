@@ -0,0 +1,54 @@
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileStore reads a corpus split in one file per SHA-1 prefix, named like
+// 21BD1.txt and holding the lines of the HIBP range API, e.g.
+// 0018A45C4D1DEF81644B54AB7F969B88D65:10
+// Only the bucket of the password is read, so nothing is loaded up front
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) FileStore {
+	return FileStore{
+		dir: dir,
+	}
+}
+
+func (s FileStore) CountPwnedPassword(ctx context.Context, prefix string, suffix string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, strings.ToUpper(prefix)+".txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+
+	suffix = strings.ToUpper(suffix)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		hash, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(hash, suffix) {
+			continue
+		}
+
+		return strconv.Atoi(count)
+	}
+
+	return 0, scanner.Err()
+}
@@ -0,0 +1,74 @@
+package pwned
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore_CountPwnedPassword(t *testing.T) {
+	dir := t.TempDir()
+
+	bucket := "1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n" +
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD9:0\r\n"
+	if err := os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(bucket), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	type args struct {
+		prefix string
+		suffix string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Find a hash in the bucket",
+			args: args{
+				prefix: "5BAA6",
+				suffix: "1E4C9B93F3F0682250B6CF8331B7EE68FD8",
+			},
+			want: 3861493,
+		},
+		{
+			name: "Find a hash ignoring the case",
+			args: args{
+				prefix: "5baa6",
+				suffix: "1e4c9b93f3f0682250b6cf8331b7ee68fd8",
+			},
+			want: 3861493,
+		},
+		{
+			name: "Do not find a hash missing from the bucket",
+			args: args{
+				prefix: "5BAA6",
+				suffix: "00000000000000000000000000000000000",
+			},
+			want: 0,
+		},
+		{
+			name: "Do not find a hash without bucket",
+			args: args{
+				prefix: "00000",
+				suffix: "1E4C9B93F3F0682250B6CF8331B7EE68FD8",
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFileStore(dir).CountPwnedPassword(context.Background(), tt.args.prefix, tt.args.suffix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountPwnedPassword() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CountPwnedPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type Store interface {
+	CountPwnedPassword(ctx context.Context, prefix string, suffix string) (int, error)
+}
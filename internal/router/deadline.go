@@ -0,0 +1,29 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	DEADLINE_MARGIN = time.Millisecond * 500
+)
+
+// WithDeadlineMargin ends the context a bit before the invocation deadline, so
+// the function still has time to answer with a timeout before Lambda stops it
+func WithDeadlineMargin(ctx context.Context, margin time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithDeadline(ctx, deadline.Add(-margin))
+}
+
+// TimedOut tells whether a failure was caused by the invocation running out of
+// time. lib/pq cancels a running query when the context ends and reports it as
+// a canceled statement, not as the context error, so the context is checked too
+func TimedOut(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded)
+}
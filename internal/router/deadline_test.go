@@ -0,0 +1,83 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDeadlineMargin(t *testing.T) {
+	t.Run("Should end the context before the invocation deadline", func(t *testing.T) {
+		// Arrange
+		deadline := time.Now().Add(time.Minute)
+		parent, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		// Act
+		ctx, cancel := WithDeadlineMargin(parent, DEADLINE_MARGIN)
+		defer cancel()
+
+		// Assert
+		got, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.Equal(t, deadline.Add(-DEADLINE_MARGIN), got)
+	})
+
+	t.Run("Should not set a deadline when the invocation has none", func(t *testing.T) {
+		// Arrange
+		parent := context.Background()
+
+		// Act
+		ctx, cancel := WithDeadlineMargin(parent, DEADLINE_MARGIN)
+		defer cancel()
+
+		// Assert
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		assert.NoError(t, ctx.Err())
+	})
+}
+
+func TestTimedOut(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{
+			name: "Should detect a query canceled because the deadline passed",
+			ctx:  expired,
+			err:  errors.New("pq: canceling statement due to user request"),
+			want: true,
+		},
+		{
+			name: "Should detect the deadline error",
+			ctx:  context.Background(),
+			err:  fmt.Errorf("query: %w", context.DeadlineExceeded),
+			want: true,
+		},
+		{
+			name: "Should not report other failures",
+			ctx:  context.Background(),
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := TimedOut(tt.ctx, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func GetHeader(req events.APIGatewayProxyRequest, name string) string {
+	for key, value := range req.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+
+	for key, values := range req.MultiValueHeaders {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+func GetBearerToken(req events.APIGatewayProxyRequest) (string, bool) {
+	return ParseBearerToken(GetHeader(req, "Authorization"))
+}
+
+func ParseBearerToken(value string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// GetBasicAuth reads the credentials of the Basic scheme, used by clients to
+// authenticate as described by RFC 6749 section 2.3.1
+func GetBasicAuth(req events.APIGatewayProxyRequest) (string, string, bool) {
+	scheme, value, found := strings.Cut(strings.TrimSpace(GetHeader(req, "Authorization")), " ")
+	if !found || !strings.EqualFold(scheme, "Basic") {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", "", false
+	}
+
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found || username == "" {
+		return "", "", false
+	}
+
+	return username, password, true
+}
+
+func GetBody(req events.APIGatewayProxyRequest) (string, error) {
+	if !req.IsBase64Encoded {
+		return req.Body, nil
+	}
+
+	body, err := base64.StdEncoding.DecodeString(req.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+func IsJson(req events.APIGatewayProxyRequest) bool {
+	return strings.HasPrefix(strings.ToLower(GetHeader(req, "Content-Type")), "application/json")
+}
+
+func GetFormValues(req events.APIGatewayProxyRequest) (url.Values, error) {
+	body, err := GetBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return url.ParseQuery(body)
+}
@@ -0,0 +1,248 @@
+package router
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestGetBearerToken(t *testing.T) {
+	type args struct {
+		req events.APIGatewayProxyRequest
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   string
+		wantOk bool
+	}{
+		{
+			name: "Get the token from the Authorization header",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Bearer token"},
+				},
+			},
+			want:   "token",
+			wantOk: true,
+		},
+		{
+			name: "Get the token ignoring the header and scheme case",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"authorization": "bearer token"},
+				},
+			},
+			want:   "token",
+			wantOk: true,
+		},
+		{
+			name: "Get the token from the multi value headers",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					MultiValueHeaders: map[string][]string{"Authorization": {"Bearer token"}},
+				},
+			},
+			want:   "token",
+			wantOk: true,
+		},
+		{
+			name: "Do not get a token with another scheme",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+				},
+			},
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name: "Do not get a token when the header is missing",
+			args: args{
+				req: events.APIGatewayProxyRequest{},
+			},
+			want:   "",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := GetBearerToken(tt.args.req)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("GetBearerToken() = (%v, %v), want (%v, %v)", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetBasicAuth(t *testing.T) {
+	type args struct {
+		req events.APIGatewayProxyRequest
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantUsername string
+		wantPassword string
+		wantOk       bool
+	}{
+		{
+			name: "Get the credentials from the Authorization header",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+				},
+			},
+			wantUsername: "user",
+			wantPassword: "pass",
+			wantOk:       true,
+		},
+		{
+			name: "Get the credentials ignoring the header and scheme case",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"authorization": "basic dXNlcjpwYXNz"},
+				},
+			},
+			wantUsername: "user",
+			wantPassword: "pass",
+			wantOk:       true,
+		},
+		{
+			name: "Do not get credentials with another scheme",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Bearer token"},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			name: "Do not get credentials that are not base64",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Basic user:pass"},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			name: "Do not get credentials without the separator",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Authorization": "Basic dXNlcg=="},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			name: "Do not get credentials when the header is missing",
+			args: args{
+				req: events.APIGatewayProxyRequest{},
+			},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUsername, gotPassword, gotOk := GetBasicAuth(tt.args.req)
+			if gotUsername != tt.wantUsername || gotPassword != tt.wantPassword || gotOk != tt.wantOk {
+				t.Errorf("GetBasicAuth() = (%v, %v, %v), want (%v, %v, %v)", gotUsername, gotPassword, gotOk, tt.wantUsername, tt.wantPassword, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetFormValues(t *testing.T) {
+	type args struct {
+		req events.APIGatewayProxyRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "Get the values from the body",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Body: "token=abc&token_type_hint=access_token",
+				},
+			},
+			want:    url.Values{"token": {"abc"}, "token_type_hint": {"access_token"}},
+			wantErr: false,
+		},
+		{
+			name: "Get the values from a base64 encoded body",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Body:            "dG9rZW49YWJj",
+					IsBase64Encoded: true,
+				},
+			},
+			want:    url.Values{"token": {"abc"}},
+			wantErr: false,
+		},
+		{
+			name: "Do not get the values from an invalid base64 body",
+			args: args{
+				req: events.APIGatewayProxyRequest{
+					Body:            "%%%",
+					IsBase64Encoded: true,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFormValues(tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFormValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFormValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsJson(t *testing.T) {
+	tests := []struct {
+		name string
+		req  events.APIGatewayProxyRequest
+		want bool
+	}{
+		{
+			name: "Json content type with charset",
+			req: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"content-type": "application/json; charset=utf-8"},
+			},
+			want: true,
+		},
+		{
+			name: "Form content type",
+			req: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			},
+			want: false,
+		},
+		{
+			name: "Missing content type",
+			req:  events.APIGatewayProxyRequest{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsJson(tt.req); got != tt.want {
+				t.Errorf("IsJson() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
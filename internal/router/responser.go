@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"log/slog"
 
@@ -18,26 +19,95 @@ func InvalidCPFOrPassword() events.APIGatewayProxyResponse {
 	return buildResponse(http.StatusUnauthorized, "invalid cpf or password", "")
 }
 
+func InvalidDocument(err error) events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusBadRequest, "invalid document: "+err.Error(), "")
+}
+
+func InvalidPassword(violations []entities.PolicyViolation) events.APIGatewayProxyResponse {
+	return writeResponse(entities.Response{
+		Status:  http.StatusBadRequest,
+		Message: "password does not meet the policy",
+		Errors:  violations,
+	})
+}
+
+func Unauthorized() events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusUnauthorized, "unauthorized", "")
+}
+
+func Forbidden() events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusForbidden, "forbidden", "")
+}
+
 func InternalServerError() events.APIGatewayProxyResponse {
 	return buildResponse(http.StatusInternalServerError, "internal server error", "")
 }
 
-func MethodNotAllowed() events.APIGatewayProxyResponse {
-	return buildResponse(http.StatusMethodNotAllowed, "method not allowed", "")
+func Timeout() events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusGatewayTimeout, "request timed out", "")
+}
+
+func NotFound() events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusNotFound, "not found", "")
+}
+
+func MethodNotAllowed(allow ...string) events.APIGatewayProxyResponse {
+	response := buildResponse(http.StatusMethodNotAllowed, "method not allowed", "")
+
+	if len(allow) > 0 {
+		response.Headers["Allow"] = strings.Join(allow, ", ")
+	}
+
+	return response
 }
 
 func Success(token string) events.APIGatewayProxyResponse {
 	return buildResponse(http.StatusOK, "success", token)
 }
 
+func SuccessWithRefreshToken(accessToken string, refreshToken string) events.APIGatewayProxyResponse {
+	return writeResponse(entities.Response{
+		Status:       http.StatusOK,
+		Message:      "success",
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+func NoContent() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusNoContent,
+	}
+}
+
+func JwkSet(set entities.JwkSet) events.APIGatewayProxyResponse {
+	response := writeJson(http.StatusOK, set)
+	response.Headers["Cache-Control"] = "public, max-age=300"
+
+	return response
+}
+
+func Introspection(introspection entities.Introspection) events.APIGatewayProxyResponse {
+	response := writeJson(http.StatusOK, introspection)
+	response.Headers["Cache-Control"] = "no-store"
+
+	return response
+}
+
 func buildResponse(status int, message string, token string) events.APIGatewayProxyResponse {
-	response := entities.Response{
+	return writeResponse(entities.Response{
 		Status:      status,
 		Message:     message,
 		AccessToken: token,
-	}
+	})
+}
+
+func writeResponse(response entities.Response) events.APIGatewayProxyResponse {
+	return writeJson(response.Status, response)
+}
 
-	body, err := json.Marshal(response)
+func writeJson(status int, v interface{}) events.APIGatewayProxyResponse {
+	body, err := json.Marshal(v)
 	if err != nil {
 		slog.Error("error while trying to marshal the response", "error", err)
 	}
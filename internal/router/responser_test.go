@@ -1,10 +1,12 @@
 package router
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 )
 
 func TestInvalidRequestBody(t *testing.T) {
@@ -57,6 +59,118 @@ func TestInvalidCPFOrPassword(t *testing.T) {
 	}
 }
 
+func TestInvalidDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "InvalidDocument",
+			err:  errors.New("cpf must have 11 digits"),
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 400,
+				Body:       `{"status":400,"message":"invalid document: cpf must have 11 digits"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InvalidDocument(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvalidDocument() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidPassword(t *testing.T) {
+	type args struct {
+		violations []entities.PolicyViolation
+	}
+	tests := []struct {
+		name string
+		args args
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "InvalidPassword",
+			args: args{
+				violations: []entities.PolicyViolation{
+					entities.NewPolicyViolation("min_length", "password must have at least 8 characters"),
+					entities.NewPolicyViolation("digit", "password must have a digit"),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 400,
+				Body:       `{"status":400,"message":"password does not meet the policy","errors":[{"rule":"min_length","message":"password must have at least 8 characters"},{"rule":"digit","message":"password must have a digit"}]}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InvalidPassword(tt.args.violations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvalidPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	tests := []struct {
+		name string
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "Unauthorized",
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 401,
+				Body:       `{"status":401,"message":"unauthorized"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unauthorized(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unauthorized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForbidden(t *testing.T) {
+	tests := []struct {
+		name string
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "Forbidden",
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 403,
+				Body:       `{"status":403,"message":"forbidden"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Forbidden(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Forbidden() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestInternalServerError(t *testing.T) {
 	tests := []struct {
 		name string
@@ -82,9 +196,63 @@ func TestInternalServerError(t *testing.T) {
 	}
 }
 
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "Timeout",
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 504,
+				Body:       `{"status":504,"message":"request timed out"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Timeout(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Timeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "NotFound",
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 404,
+				Body:       `{"status":404,"message":"not found"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NotFound(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NotFound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMethodNotAllowed(t *testing.T) {
+	type args struct {
+		allow []string
+	}
 	tests := []struct {
 		name string
+		args args
 		want events.APIGatewayProxyResponse
 	}{
 		{
@@ -97,10 +265,24 @@ func TestMethodNotAllowed(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "MethodNotAllowed with allowed methods",
+			args: args{
+				allow: []string{"GET", "POST"},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 405,
+				Body:       `{"status":405,"message":"method not allowed"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+					"Allow":        "GET, POST",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := MethodNotAllowed(); !reflect.DeepEqual(got, tt.want) {
+			if got := MethodNotAllowed(tt.args.allow...); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("MethodNotAllowed() = %v, want %v", got, tt.want)
 			}
 		})
@@ -138,3 +320,166 @@ func TestSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestSuccessWithRefreshToken(t *testing.T) {
+	type args struct {
+		accessToken  string
+		refreshToken string
+	}
+	tests := []struct {
+		name string
+		args args
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "SuccessWithRefreshToken",
+			args: args{
+				accessToken:  "token",
+				refreshToken: "refresh",
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 200,
+				Body:       `{"status":200,"message":"success","access_token":"token","refresh_token":"refresh"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuccessWithRefreshToken(tt.args.accessToken, tt.args.refreshToken); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuccessWithRefreshToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoContent(t *testing.T) {
+	tests := []struct {
+		name string
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "NoContent",
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 204,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NoContent(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NoContent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJwkSet(t *testing.T) {
+	type args struct {
+		set entities.JwkSet
+	}
+	tests := []struct {
+		name string
+		args args
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "JwkSet",
+			args: args{
+				set: entities.JwkSet{
+					Keys: []entities.Jwk{
+						{Kty: "EC", Use: "sig", Alg: "ES256", Kid: "1", Crv: "P-256", X: "x", Y: "y"},
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 200,
+				Body:       `{"keys":[{"kty":"EC","use":"sig","alg":"ES256","kid":"1","crv":"P-256","x":"x","y":"y"}]}`,
+				Headers: map[string]string{
+					"Content-Type":  "application/json",
+					"Cache-Control": "public, max-age=300",
+				},
+			},
+		},
+		{
+			name: "JwkSet without keys",
+			args: args{
+				set: entities.JwkSet{
+					Keys: []entities.Jwk{},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 200,
+				Body:       `{"keys":[]}`,
+				Headers: map[string]string{
+					"Content-Type":  "application/json",
+					"Cache-Control": "public, max-age=300",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JwkSet(tt.args.set); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JwkSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	isAnonymous := true
+
+	type args struct {
+		introspection entities.Introspection
+	}
+	tests := []struct {
+		name string
+		args args
+		want events.APIGatewayProxyResponse
+	}{
+		{
+			name: "Introspection of an active token",
+			args: args{
+				introspection: entities.Introspection{
+					Active:      true,
+					TokenType:   "Bearer",
+					Subject:     "1",
+					ExpiresAt:   1713055031,
+					IsAnonymous: &isAnonymous,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 200,
+				Body:       `{"active":true,"token_type":"Bearer","sub":"1","exp":1713055031,"is_anonymous":true}`,
+				Headers: map[string]string{
+					"Content-Type":  "application/json",
+					"Cache-Control": "no-store",
+				},
+			},
+		},
+		{
+			name: "Introspection of an inactive token",
+			args: args{
+				introspection: entities.NewInactiveIntrospection(),
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: 200,
+				Body:       `{"active":false}`,
+				Headers: map[string]string{
+					"Content-Type":  "application/json",
+					"Cache-Control": "no-store",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Introspection(tt.args.introspection); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Introspection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type HandlerFunc func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+type route struct {
+	method   string
+	segments []string
+	handler  HandlerFunc
+}
+
+type Router struct {
+	routes []route
+}
+
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers the handler for the given method and path pattern.
+// Path parameters are declared between braces, e.g. /customers/{id}.
+func (r *Router) Handle(method string, pattern string, handler HandlerFunc) {
+	segments := splitPath(pattern)
+
+	for _, segment := range segments {
+		if isParam(segment) && paramName(segment) == "" {
+			panic(fmt.Sprintf("router: empty path parameter name in pattern %q", pattern))
+		}
+	}
+
+	for _, existing := range r.routes {
+		if existing.method == method && samePattern(existing.segments, segments) {
+			panic(fmt.Sprintf("router: route %s %s is already registered", method, pattern))
+		}
+	}
+
+	r.routes = append(r.routes, route{
+		method:   method,
+		segments: segments,
+		handler:  handler,
+	})
+}
+
+// Route dispatches the request to the most specific route matching its path.
+// When the path matches but the method does not a 405 with the Allow header is
+// returned, otherwise a 404.
+func (r *Router) Route(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	segments := splitPath(req.Path)
+
+	var (
+		best       *route
+		bestParams map[string]string
+		bestScore  = -1
+		allowed    = make(map[string]struct{})
+	)
+
+	for i := range r.routes {
+		candidate := &r.routes[i]
+
+		params, score, ok := candidate.match(segments)
+		if !ok {
+			continue
+		}
+
+		allowed[candidate.method] = struct{}{}
+
+		if candidate.method != req.HTTPMethod {
+			continue
+		}
+
+		if score > bestScore {
+			best = candidate
+			bestParams = params
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		if len(allowed) > 0 {
+			return MethodNotAllowed(sortedMethods(allowed)...), nil
+		}
+
+		return NotFound(), nil
+	}
+
+	if len(bestParams) > 0 {
+		pathParameters := make(map[string]string, len(req.PathParameters)+len(bestParams))
+		for k, v := range req.PathParameters {
+			pathParameters[k] = v
+		}
+		for k, v := range bestParams {
+			pathParameters[k] = v
+		}
+		req.PathParameters = pathParameters
+	}
+
+	return best.handler(ctx, req)
+}
+
+func PathParam(req events.APIGatewayProxyRequest, name string) string {
+	return req.PathParameters[name]
+}
+
+func (rt *route) match(segments []string) (map[string]string, int, bool) {
+	if len(rt.segments) != len(segments) {
+		return nil, 0, false
+	}
+
+	var params map[string]string
+	score := 0
+
+	for i, segment := range rt.segments {
+		if isParam(segment) {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[paramName(segment)] = segments[i]
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, 0, false
+		}
+
+		score++
+	}
+
+	return params, score, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func paramName(segment string) string {
+	return strings.TrimSpace(segment[1 : len(segment)-1])
+}
+
+func samePattern(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if isParam(a[i]) && isParam(b[i]) {
+			continue
+		}
+
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedMethods(methods map[string]struct{}) []string {
+	out := make([]string, 0, len(methods))
+	for method := range methods {
+		out = append(out, method)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
@@ -0,0 +1,159 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func respondWith(status int) HandlerFunc {
+	return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: status}, nil
+	}
+}
+
+func TestRouter_Route(t *testing.T) {
+	t.Run("Should dispatch to the handler registered for the method and path", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodPost, "/register", respondWith(http.StatusOK))
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/register",
+			HTTPMethod: http.MethodPost,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, got.StatusCode)
+	})
+
+	t.Run("Should ignore trailing slashes", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodPost, "/register", respondWith(http.StatusOK))
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/register/",
+			HTTPMethod: http.MethodPost,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, got.StatusCode)
+	})
+
+	t.Run("Should expose the path parameters to the handler", func(t *testing.T) {
+		// Arrange
+		var gotId string
+
+		r := NewRouter()
+		r.Handle(http.MethodGet, "/customers/{id}", func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			gotId = PathParam(req, "id")
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+		})
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/customers/abc-123",
+			HTTPMethod: http.MethodGet,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, got.StatusCode)
+		assert.Equal(t, "abc-123", gotId)
+	})
+
+	t.Run("Should prefer static segments over path parameters", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodGet, "/customers/{id}", respondWith(http.StatusOK))
+		r.Handle(http.MethodGet, "/customers/me", respondWith(http.StatusAccepted))
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/customers/me",
+			HTTPMethod: http.MethodGet,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, got.StatusCode)
+	})
+
+	t.Run("Should return not found when no route matches the path", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodPost, "/register", respondWith(http.StatusOK))
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/unknown",
+			HTTPMethod: http.MethodPost,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, got.StatusCode)
+		assert.NotContains(t, got.Headers, "Allow")
+	})
+
+	t.Run("Should return method not allowed with the allowed methods", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodPut, "/customers/{id}", respondWith(http.StatusOK))
+		r.Handle(http.MethodGet, "/customers/{id}", respondWith(http.StatusOK))
+
+		req := events.APIGatewayProxyRequest{
+			Path:       "/customers/abc-123",
+			HTTPMethod: http.MethodDelete,
+		}
+
+		// Act
+		got, err := r.Route(context.Background(), req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusMethodNotAllowed, got.StatusCode)
+		assert.Equal(t, "GET, PUT", got.Headers["Allow"])
+	})
+}
+
+func TestRouter_Handle(t *testing.T) {
+	t.Run("Should panic when the route is already registered", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+		r.Handle(http.MethodGet, "/customers/{id}", respondWith(http.StatusOK))
+
+		// Act & Assert
+		assert.Panics(t, func() {
+			r.Handle(http.MethodGet, "/customers/{customerId}", respondWith(http.StatusOK))
+		})
+	})
+
+	t.Run("Should panic when a path parameter has no name", func(t *testing.T) {
+		// Arrange
+		r := NewRouter()
+
+		// Act & Assert
+		assert.Panics(t, func() {
+			r.Handle(http.MethodGet, "/customers/{}", respondWith(http.StatusOK))
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ALGORITHM_HS256 = "HS256"
+	ALGORITHM_RS256 = "RS256"
+	ALGORITHM_ES256 = "ES256"
+
+	DEFAULT_ISSUER   = "lambda-register"
+	DEFAULT_AUDIENCE = "fastfood"
+	DEFAULT_TTL      = time.Hour * 2
+)
+
+type KeyConfig struct {
+	Id         string
+	Algorithm  string
+	SecretKey  []byte
+	PrivateKey []byte
+	Active     bool
+	RetireAt   time.Time
+}
+
+type Config struct {
+	Keys     []KeyConfig
+	Issuer   string
+	Audience []string
+	TTL      time.Duration
+}
+
+type keyConfigEnv struct {
+	Id         string    `json:"kid"`
+	Algorithm  string    `json:"alg"`
+	SecretKey  string    `json:"secret"`
+	PrivateKey string    `json:"private_key"`
+	Active     bool      `json:"active"`
+	RetireAt   time.Time `json:"retire_at"`
+}
+
+// NewConfigFromEnv loads the claims settings from the TOKEN_* variables and the
+// key ring from SIGN_KEYS, a JSON array of keys, falling back to a single active
+// key described by the SIGN_* variables
+func NewConfigFromEnv() (Config, error) {
+	config := Config{
+		Issuer:   DEFAULT_ISSUER,
+		Audience: []string{DEFAULT_AUDIENCE},
+		TTL:      DEFAULT_TTL,
+	}
+
+	if issuer := os.Getenv("TOKEN_ISSUER"); issuer != "" {
+		config.Issuer = issuer
+	}
+
+	if audience := os.Getenv("TOKEN_AUDIENCE"); audience != "" {
+		config.Audience = splitList(audience)
+	}
+
+	if ttl := os.Getenv("TOKEN_TTL"); ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil || duration <= 0 {
+			return Config{}, fmt.Errorf("invalid TOKEN_TTL %q", ttl)
+		}
+		config.TTL = duration
+	}
+
+	keys, err := newKeyConfigsFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.Keys = keys
+
+	return config, nil
+}
+
+func newKeyConfigsFromEnv() ([]KeyConfig, error) {
+	if raw := os.Getenv("SIGN_KEYS"); raw != "" {
+		var keys []keyConfigEnv
+		if err := json.Unmarshal([]byte(raw), &keys); err != nil {
+			return nil, fmt.Errorf("error parsing SIGN_KEYS: %w", err)
+		}
+
+		configs := make([]KeyConfig, 0, len(keys))
+
+		for _, key := range keys {
+			configs = append(configs, KeyConfig{
+				Id:         key.Id,
+				Algorithm:  key.Algorithm,
+				SecretKey:  []byte(key.SecretKey),
+				PrivateKey: []byte(key.PrivateKey),
+				Active:     key.Active,
+				RetireAt:   key.RetireAt,
+			})
+		}
+
+		return configs, nil
+	}
+
+	algorithm := os.Getenv("SIGN_ALGORITHM")
+	if algorithm == "" {
+		algorithm = ALGORITHM_HS256
+	}
+
+	return []KeyConfig{
+		{
+			Id:         os.Getenv("SIGN_KEY_ID"),
+			Algorithm:  algorithm,
+			SecretKey:  []byte(os.Getenv("SIGN_KEY")),
+			PrivateKey: []byte(os.Getenv("SIGN_PRIVATE_KEY")),
+			Active:     true,
+		},
+	}, nil
+}
+
+func splitList(s string) []string {
+	out := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("Should load a single active key from the SIGN_* variables", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SIGN_KEYS", "")
+		t.Setenv("SIGN_ALGORITHM", "")
+		t.Setenv("SIGN_KEY_ID", "")
+		t.Setenv("SIGN_KEY", "key")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, got.Keys, 1)
+		assert.Equal(t, ALGORITHM_HS256, got.Keys[0].Algorithm)
+		assert.Equal(t, []byte("key"), got.Keys[0].SecretKey)
+		assert.True(t, got.Keys[0].Active)
+	})
+
+	t.Run("Should load the key ring from SIGN_KEYS", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SIGN_KEYS", `[
+			{"kid":"2024-01","alg":"HS256","secret":"old","retire_at":"2024-03-01T00:00:00Z"},
+			{"kid":"2024-02","alg":"HS256","secret":"new","active":true}
+		]`)
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []KeyConfig{
+			{
+				Id:         "2024-01",
+				Algorithm:  ALGORITHM_HS256,
+				SecretKey:  []byte("old"),
+				PrivateKey: []byte(""),
+				RetireAt:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Id:         "2024-02",
+				Algorithm:  ALGORITHM_HS256,
+				SecretKey:  []byte("new"),
+				PrivateKey: []byte(""),
+				Active:     true,
+			},
+		}, got.Keys)
+	})
+
+	t.Run("Should load the claims settings", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SIGN_KEY", "key")
+		t.Setenv("TOKEN_ISSUER", "https://register.fastfood")
+		t.Setenv("TOKEN_AUDIENCE", "orders, payments")
+		t.Setenv("TOKEN_TTL", "15m")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://register.fastfood", got.Issuer)
+		assert.Equal(t, []string{"orders", "payments"}, got.Audience)
+		assert.Equal(t, time.Minute*15, got.TTL)
+	})
+
+	t.Run("Should use the default claims settings", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SIGN_KEY", "key")
+		t.Setenv("TOKEN_ISSUER", "")
+		t.Setenv("TOKEN_AUDIENCE", "")
+		t.Setenv("TOKEN_TTL", "")
+
+		// Act
+		got, err := NewConfigFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, DEFAULT_ISSUER, got.Issuer)
+		assert.Equal(t, []string{DEFAULT_AUDIENCE}, got.Audience)
+		assert.Equal(t, DEFAULT_TTL, got.TTL)
+	})
+
+	t.Run("Should return an error when TOKEN_TTL is invalid", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TOKEN_TTL", "two hours")
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when SIGN_KEYS is malformed", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SIGN_KEYS", `[{`)
+
+		// Act
+		_, err := NewConfigFromEnv()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	entities "github.com/jfelipearaujo-org/lambda-register/internal/entities"
 
 	mock "github.com/stretchr/testify/mock"
@@ -41,6 +43,105 @@ func (_m *MockToken) CreateJwtToken(user entities.User) (string, error) {
 	return r0, r1
 }
 
+// CreateRefreshToken provides a mock function with given fields:
+func (_m *MockToken) CreateRefreshToken() (string, string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (string, string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() string); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetJwks provides a mock function with given fields:
+func (_m *MockToken) GetJwks() entities.JwkSet {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJwks")
+	}
+
+	var r0 entities.JwkSet
+	if rf, ok := ret.Get(0).(func() entities.JwkSet); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(entities.JwkSet)
+	}
+
+	return r0
+}
+
+// HashRefreshToken provides a mock function with given fields: refreshToken
+func (_m *MockToken) HashRefreshToken(refreshToken string) string {
+	ret := _m.Called(refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HashRefreshToken")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// VerifyJwtToken provides a mock function with given fields: ctx, tokenString
+func (_m *MockToken) VerifyJwtToken(ctx context.Context, tokenString string) (entities.Claims, error) {
+	ret := _m.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyJwtToken")
+	}
+
+	var r0 entities.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entities.Claims, error)); ok {
+		return rf(ctx, tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.Claims); ok {
+		r0 = rf(ctx, tokenString)
+	} else {
+		r0 = ret.Get(0).(entities.Claims)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockToken creates a new instance of MockToken. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockToken(t interface {
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type RevocationStore interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
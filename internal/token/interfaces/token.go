@@ -1,9 +1,15 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
 )
 
 type Token interface {
 	CreateJwtToken(user entities.User) (string, error)
+	VerifyJwtToken(ctx context.Context, tokenString string) (entities.Claims, error)
+	CreateRefreshToken() (string, string, error)
+	HashRefreshToken(refreshToken string) string
+	GetJwks() entities.JwkSet
 }
@@ -0,0 +1,102 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrNoActiveKey        = errors.New("no active signing key")
+	ErrMultipleActiveKeys = errors.New("more than one active signing key")
+	ErrDuplicateKeyId     = errors.New("duplicated signing key id")
+	ErrActiveKeyRetired   = errors.New("the active signing key is scheduled to be retired")
+)
+
+// keyRing holds the key used to sign new tokens and the older keys that are
+// still accepted when verifying tokens until their retirement date
+type keyRing struct {
+	active string
+	keys   map[string]signingKey
+	order  []string
+}
+
+func newKeyRing(configs []KeyConfig) (keyRing, error) {
+	ring := keyRing{
+		keys: make(map[string]signingKey, len(configs)),
+	}
+
+	for _, config := range configs {
+		key, err := newSigningKey(config)
+		if err != nil {
+			return keyRing{}, fmt.Errorf("key %q: %w", config.Id, err)
+		}
+
+		if _, exists := ring.keys[key.id]; exists {
+			return keyRing{}, fmt.Errorf("%w: %s", ErrDuplicateKeyId, key.id)
+		}
+
+		if config.Active {
+			if ring.active != "" {
+				return keyRing{}, ErrMultipleActiveKeys
+			}
+
+			if !key.retireAt.IsZero() {
+				return keyRing{}, fmt.Errorf("%w: %s", ErrActiveKeyRetired, key.id)
+			}
+
+			ring.active = key.id
+		}
+
+		ring.keys[key.id] = key
+		ring.order = append(ring.order, key.id)
+	}
+
+	if ring.active == "" {
+		return keyRing{}, ErrNoActiveKey
+	}
+
+	return ring, nil
+}
+
+func (r keyRing) signingKey() signingKey {
+	return r.keys[r.active]
+}
+
+func (r keyRing) verificationKey(kid string, now time.Time) (signingKey, bool) {
+	key, ok := r.keys[kid]
+	if !ok || key.isRetired(now) {
+		return signingKey{}, false
+	}
+
+	return key, true
+}
+
+func (r keyRing) algorithms() []string {
+	unique := make(map[string]struct{})
+	for _, key := range r.keys {
+		unique[key.method.Alg()] = struct{}{}
+	}
+
+	out := make([]string, 0, len(unique))
+	for alg := range unique {
+		out = append(out, alg)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
+
+// published returns the keys in configuration order that can still verify tokens
+func (r keyRing) published(now time.Time) []signingKey {
+	out := make([]signingKey, 0, len(r.order))
+	for _, kid := range r.order {
+		if key := r.keys[kid]; !key.isRetired(now) {
+			out = append(out, key)
+		}
+	}
+
+	return out
+}
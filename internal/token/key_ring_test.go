@@ -0,0 +1,159 @@
+package token
+
+import (
+	"context"
+	"crypto/elliptic"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKeyRing(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []KeyConfig
+		wantErr error
+	}{
+		{
+			name: "Create a key ring with one active key and older keys",
+			configs: []KeyConfig{
+				{Id: "old", Algorithm: ALGORITHM_HS256, SecretKey: []byte("old"), RetireAt: time.Now().Add(time.Hour)},
+				{Id: "new", Algorithm: ALGORITHM_HS256, SecretKey: []byte("new"), Active: true},
+			},
+		},
+		{
+			name: "Do not create a key ring without an active key",
+			configs: []KeyConfig{
+				{Id: "old", Algorithm: ALGORITHM_HS256, SecretKey: []byte("old")},
+			},
+			wantErr: ErrNoActiveKey,
+		},
+		{
+			name: "Do not create a key ring with more than one active key",
+			configs: []KeyConfig{
+				{Id: "old", Algorithm: ALGORITHM_HS256, SecretKey: []byte("old"), Active: true},
+				{Id: "new", Algorithm: ALGORITHM_HS256, SecretKey: []byte("new"), Active: true},
+			},
+			wantErr: ErrMultipleActiveKeys,
+		},
+		{
+			name: "Do not create a key ring with duplicated kids",
+			configs: []KeyConfig{
+				{Id: "key", Algorithm: ALGORITHM_HS256, SecretKey: []byte("old")},
+				{Id: "key", Algorithm: ALGORITHM_HS256, SecretKey: []byte("new"), Active: true},
+			},
+			wantErr: ErrDuplicateKeyId,
+		},
+		{
+			name: "Do not create a key ring whose active key is scheduled to retire",
+			configs: []KeyConfig{
+				{Id: "key", Algorithm: ALGORITHM_HS256, SecretKey: []byte("key"), Active: true, RetireAt: time.Now().Add(time.Hour)},
+			},
+			wantErr: ErrActiveKeyRetired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeyRing(tt.configs)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestToken_KeyRotation(t *testing.T) {
+	oldKey := KeyConfig{
+		Id:         "2024-01",
+		Algorithm:  ALGORITHM_ES256,
+		PrivateKey: generateECPrivateKeyPEM(t, elliptic.P256()),
+	}
+	newKey := KeyConfig{
+		Id:         "2024-02",
+		Algorithm:  ALGORITHM_RS256,
+		PrivateKey: generateRSAPrivateKeyPEM(t),
+	}
+
+	newTokenWith := func(t *testing.T, keys ...KeyConfig) Token {
+		token, err := NewToken(Config{Keys: keys}, providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+		if err != nil {
+			t.Fatalf("NewToken() error = %v", err)
+		}
+		return token
+	}
+
+	activeOld := oldKey
+	activeOld.Active = true
+
+	beforeRotation := newTokenWith(t, activeOld)
+
+	signedBefore, err := beforeRotation.CreateJwtToken(entities.User{Id: "1"})
+	if err != nil {
+		t.Fatalf("CreateJwtToken() error = %v", err)
+	}
+
+	retiringOld := oldKey
+	retiringOld.RetireAt = time.Now().Add(time.Hour)
+
+	activeNew := newKey
+	activeNew.Active = true
+
+	afterRotation := newTokenWith(t, retiringOld, activeNew)
+
+	signedAfter, err := afterRotation.CreateJwtToken(entities.User{Id: "2"})
+	if err != nil {
+		t.Fatalf("CreateJwtToken() error = %v", err)
+	}
+
+	retiredOld := oldKey
+	retiredOld.RetireAt = time.Now().Add(-time.Minute)
+
+	afterRetirement := newTokenWith(t, retiredOld, activeNew)
+
+	t.Run("Should keep accepting tokens signed before the rotation", func(t *testing.T) {
+		got, err := afterRotation.VerifyJwtToken(context.Background(), signedBefore)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", got.Subject)
+	})
+
+	t.Run("Should accept tokens signed with the new active key", func(t *testing.T) {
+		got, err := afterRotation.VerifyJwtToken(context.Background(), signedAfter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2", got.Subject)
+	})
+
+	t.Run("Should publish both keys while the old one is not retired", func(t *testing.T) {
+		got := afterRotation.GetJwks()
+
+		assert.Len(t, got.Keys, 2)
+		assert.Equal(t, "2024-01", got.Keys[0].Kid)
+		assert.Equal(t, "2024-02", got.Keys[1].Kid)
+	})
+
+	t.Run("Should reject tokens signed with a retired key", func(t *testing.T) {
+		_, err := afterRetirement.VerifyJwtToken(context.Background(), signedBefore)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Should keep accepting tokens signed with the active key after a retirement", func(t *testing.T) {
+		got, err := afterRetirement.VerifyJwtToken(context.Background(), signedAfter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2", got.Subject)
+	})
+
+	t.Run("Should stop publishing retired keys", func(t *testing.T) {
+		got := afterRetirement.GetJwks()
+
+		assert.Len(t, got.Keys, 1)
+		assert.Equal(t, "2024-02", got.Keys[0].Kid)
+	})
+}
@@ -0,0 +1,162 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+)
+
+const (
+	defaultSymmetricKeyId = "default"
+)
+
+var (
+	ErrMissingSigningKey        = errors.New("missing signing key")
+	ErrUnsupportedAlgorithm     = errors.New("unsupported signing algorithm")
+	ErrUnsupportedEllipticCurve = errors.New("unsupported elliptic curve, ES256 requires P-256")
+)
+
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	retireAt  time.Time
+}
+
+func newSigningKey(config KeyConfig) (signingKey, error) {
+	key := signingKey{
+		id:       config.Id,
+		retireAt: config.RetireAt,
+	}
+
+	switch config.Algorithm {
+	case ALGORITHM_HS256:
+		if len(config.SecretKey) == 0 {
+			return signingKey{}, ErrMissingSigningKey
+		}
+
+		key.method = jwt.SigningMethodHS256
+		key.signKey = config.SecretKey
+		key.verifyKey = config.SecretKey
+
+		if key.id == "" {
+			key.id = defaultSymmetricKeyId
+		}
+
+		return key, nil
+	case ALGORITHM_RS256:
+		if len(config.PrivateKey) == 0 {
+			return signingKey{}, ErrMissingSigningKey
+		}
+
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(config.PrivateKey)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("error parsing the RSA private key: %w", err)
+		}
+
+		key.method = jwt.SigningMethodRS256
+		key.signKey = privateKey
+		key.verifyKey = &privateKey.PublicKey
+	case ALGORITHM_ES256:
+		if len(config.PrivateKey) == 0 {
+			return signingKey{}, ErrMissingSigningKey
+		}
+
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM(config.PrivateKey)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("error parsing the EC private key: %w", err)
+		}
+
+		if privateKey.Curve != elliptic.P256() {
+			return signingKey{}, ErrUnsupportedEllipticCurve
+		}
+
+		key.method = jwt.SigningMethodES256
+		key.signKey = privateKey
+		key.verifyKey = &privateKey.PublicKey
+	default:
+		return signingKey{}, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, config.Algorithm)
+	}
+
+	jwk, _ := key.jwk()
+
+	if key.id == "" {
+		key.id = thumbprint(jwk)
+	}
+
+	return key, nil
+}
+
+func (k signingKey) isRetired(now time.Time) bool {
+	return !k.retireAt.IsZero() && !now.Before(k.retireAt)
+}
+
+// jwk returns the public part of the key, symmetric keys are never published
+func (k signingKey) jwk() (entities.Jwk, bool) {
+	switch publicKey := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		return entities.Jwk{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: k.method.Alg(),
+			Kid: k.id,
+			N:   encodeBase64(publicKey.N.Bytes()),
+			E:   encodeBase64(big.NewInt(int64(publicKey.E)).Bytes()),
+		}, true
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+
+		return entities.Jwk{
+			Kty: "EC",
+			Use: "sig",
+			Alg: k.method.Alg(),
+			Kid: k.id,
+			Crv: publicKey.Curve.Params().Name,
+			X:   encodeBase64(publicKey.X.FillBytes(make([]byte, size))),
+			Y:   encodeBase64(publicKey.Y.FillBytes(make([]byte, size))),
+		}, true
+	default:
+		return entities.Jwk{}, false
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the key, used as the default kid
+func thumbprint(jwk entities.Jwk) string {
+	var members interface{}
+
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	}
+
+	body, _ := json.Marshal(members)
+	sum := sha256.Sum256(body)
+
+	return encodeBase64(sum[:])
+}
+
+func encodeBase64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,160 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func generateRSAPrivateKeyPEM(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating RSA key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+func generateECPrivateKeyPEM(t *testing.T, curve elliptic.Curve) []byte {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating EC key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("error marshaling EC key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	})
+}
+
+func TestNewSigningKey(t *testing.T) {
+	rsaKey := generateRSAPrivateKeyPEM(t)
+	ecKey := generateECPrivateKeyPEM(t, elliptic.P256())
+	ecP384Key := generateECPrivateKeyPEM(t, elliptic.P384())
+
+	tests := []struct {
+		name    string
+		config  KeyConfig
+		wantKid string
+		wantErr bool
+	}{
+		{
+			name: "Create a HS256 key",
+			config: KeyConfig{
+				Algorithm: ALGORITHM_HS256,
+				SecretKey: []byte("key"),
+			},
+			wantKid: defaultSymmetricKeyId,
+		},
+		{
+			name: "Create a HS256 key with a custom kid",
+			config: KeyConfig{
+				Algorithm: ALGORITHM_HS256,
+				Id:        "hs-1",
+				SecretKey: []byte("key"),
+			},
+			wantKid: "hs-1",
+		},
+		{
+			name: "Do not create a HS256 key without a secret",
+			config: KeyConfig{
+				Algorithm: ALGORITHM_HS256,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Create a RS256 key",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_RS256,
+				Id:         "rs-1",
+				PrivateKey: rsaKey,
+			},
+			wantKid: "rs-1",
+		},
+		{
+			name: "Create a ES256 key",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_ES256,
+				Id:         "es-1",
+				PrivateKey: ecKey,
+			},
+			wantKid: "es-1",
+		},
+		{
+			name: "Do not create a ES256 key from a P-384 private key",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_ES256,
+				PrivateKey: ecP384Key,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Do not create a RS256 key from an EC private key",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_RS256,
+				PrivateKey: ecKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Do not create a RS256 key without a private key",
+			config: KeyConfig{
+				Algorithm: ALGORITHM_RS256,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Do not create a key with an unsupported algorithm",
+			config: KeyConfig{
+				Algorithm: "none",
+				SecretKey: []byte("key"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newSigningKey(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.id != tt.wantKid {
+				t.Errorf("newSigningKey().id = %v, want %v", got.id, tt.wantKid)
+			}
+		})
+	}
+}
+
+func TestNewSigningKey_DefaultKidIsThumbprint(t *testing.T) {
+	config := KeyConfig{
+		Algorithm:  ALGORITHM_ES256,
+		PrivateKey: generateECPrivateKeyPEM(t, elliptic.P256()),
+	}
+
+	first, err := newSigningKey(config)
+	if err != nil {
+		t.Fatalf("newSigningKey() error = %v", err)
+	}
+
+	second, err := newSigningKey(config)
+	if err != nil {
+		t.Fatalf("newSigningKey() error = %v", err)
+	}
+
+	if first.id == "" || first.id != second.id {
+		t.Errorf("newSigningKey().id = %v and %v, want the same non empty thumbprint", first.id, second.id)
+	}
+}
@@ -0,0 +1,30 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	refreshTokenSize = 32
+)
+
+// CreateRefreshToken returns an opaque refresh token and the hash that must be
+// persisted in its place
+func (t Token) CreateRefreshToken() (string, string, error) {
+	buf := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	refreshToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	return refreshToken, t.HashRefreshToken(refreshToken), nil
+}
+
+func (t Token) HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,48 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRefreshToken(t *testing.T) {
+	// Arrange
+	token := newTestToken(t)
+
+	// Act
+	first, firstHash, err := token.CreateRefreshToken()
+	assert.NoError(t, err)
+
+	second, secondHash, err := token.CreateRefreshToken()
+	assert.NoError(t, err)
+
+	// Assert
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, first, firstHash)
+	assert.Equal(t, token.HashRefreshToken(first), firstHash)
+	assert.Equal(t, token.HashRefreshToken(second), secondHash)
+}
+
+func TestHashRefreshToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		refreshToken string
+		want         string
+	}{
+		{
+			name:         "Hash a refresh token",
+			refreshToken: "refresh",
+			want:         "d6cc0a088c07683c65cd266860cab8d94b3a1937b17420d9da30ca299c09fb77",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := newTestToken(t)
+			if got := token.HashRefreshToken(tt.refreshToken); got != tt.want {
+				t.Errorf("HashRefreshToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,162 @@
 package token
 
 import (
-	"os"
+	"context"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/lambda-register/internal/document"
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers/interfaces"
+	token_interfaces "github.com/jfelipearaujo-org/lambda-register/internal/token/interfaces"
 )
 
 var (
-	signingKey = []byte(os.Getenv("SIGN_KEY"))
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenRevoked = errors.New("token revoked")
 )
 
 type Token struct {
+	keys         keyRing
+	issuer       string
+	audience     []string
+	ttl          time.Duration
+	timeProvider interfaces.TimeProvider
+	revocations  token_interfaces.RevocationStore
+	documents    document.Registry
 }
 
-func NewToken() Token {
-	return Token{}
+func NewToken(config Config, timeProvider interfaces.TimeProvider, revocations token_interfaces.RevocationStore) (Token, error) {
+	keys, err := newKeyRing(config.Keys)
+	if err != nil {
+		return Token{}, err
+	}
+
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = DEFAULT_TTL
+	}
+
+	return Token{
+		keys:         keys,
+		issuer:       config.Issuer,
+		audience:     config.Audience,
+		ttl:          ttl,
+		timeProvider: timeProvider,
+		revocations:  revocations,
+		documents:    document.NewDefaultRegistry(),
+	}, nil
 }
 
 func (t Token) CreateJwtToken(user entities.User) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": user.Id,
-		"exp": time.Now().Add(time.Hour * 2).Unix(),
-	})
+	key := t.keys.signingKey()
+	now := t.timeProvider.GetTime()
+
+	claims := entities.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    t.issuer,
+			Subject:   user.Id,
+			Audience:  t.audience,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+		},
+		IsAnonymous: user.IsAnonymous,
+	}
+
+	if !user.IsAnonymous {
+		claims.Document = t.maskDocument(user)
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+
+	return token.SignedString(key.signKey)
+}
+
+// VerifyJwtToken checks the signature, the time based claims, the issuer, the
+// audience and the revocation of the token, returning its claims when it can
+// be trusted
+func (t Token) VerifyJwtToken(ctx context.Context, tokenString string) (entities.Claims, error) {
+	var claims entities.Claims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key, ok := t.keys.verificationKey(kid, t.timeProvider.GetTime())
+		if !ok || key.method.Alg() != token.Method.Alg() {
+			return nil, ErrInvalidToken
+		}
+
+		return key.verifyKey, nil
+	},
+		jwt.WithValidMethods(t.keys.algorithms()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithTimeFunc(t.timeProvider.GetTime))
+	if err != nil {
+		return entities.Claims{}, errors.Join(ErrInvalidToken, err)
+	}
+
+	if claims.Subject == "" || claims.ID == "" {
+		return entities.Claims{}, ErrInvalidToken
+	}
+
+	if !t.isAudienceAccepted(claims.Audience) {
+		return entities.Claims{}, errors.Join(ErrInvalidToken, jwt.ErrTokenInvalidAudience)
+	}
+
+	revoked, err := t.revocations.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return entities.Claims{}, err
+	}
+
+	if revoked {
+		return entities.Claims{}, errors.Join(ErrInvalidToken, ErrTokenRevoked)
+	}
+
+	return claims, nil
+}
+
+func (t Token) isAudienceAccepted(audience jwt.ClaimStrings) bool {
+	if len(t.audience) == 0 {
+		return true
+	}
+
+	for _, expected := range t.audience {
+		for _, got := range audience {
+			if got == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (t Token) maskDocument(user entities.User) string {
+	document, err := t.documents.New(user.DocumentType, user.DocumentId)
+	if err != nil || !document.IsValid() {
+		return ""
+	}
+
+	return document.Mask()
+}
+
+func (t Token) GetJwks() entities.JwkSet {
+	set := entities.JwkSet{
+		Keys: []entities.Jwk{},
+	}
+
+	for _, key := range t.keys.published(t.timeProvider.GetTime()) {
+		if jwk, ok := key.jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
 
-	return token.SignedString(signingKey)
+	return set
 }
@@ -1,11 +1,73 @@
 package token
 
 import (
+	"context"
+	"crypto/elliptic"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+	token_interface_mock "github.com/jfelipearaujo-org/lambda-register/internal/token/interfaces/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+func newRevocationStoreMock(t *testing.T) *token_interface_mock.MockRevocationStore {
+	revocations := token_interface_mock.NewMockRevocationStore(t)
+	revocations.On("IsTokenRevoked", mock.Anything, mock.Anything).
+		Return(false, nil).
+		Maybe()
+
+	return revocations
+}
+
+func newConfig(key KeyConfig) Config {
+	key.Active = true
+
+	return Config{
+		Keys: []KeyConfig{key},
+	}
+}
+
+func newTestToken(t *testing.T) Token {
+	token, err := NewToken(newConfig(KeyConfig{
+		Algorithm: ALGORITHM_HS256,
+		SecretKey: []byte("key"),
+	}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	return token
+}
+
+func TestNewToken(t *testing.T) {
+	t.Run("Should return a new instance correctly", func(t *testing.T) {
+		// Act
+		got, err := NewToken(newConfig(KeyConfig{
+			Algorithm: ALGORITHM_HS256,
+			SecretKey: []byte("key"),
+		}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.IsType(t, Token{}, got)
+	})
+
+	t.Run("Should return an error when the key is invalid", func(t *testing.T) {
+		// Act
+		_, err := NewToken(newConfig(KeyConfig{
+			Algorithm: ALGORITHM_RS256,
+		}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrMissingSigningKey)
+	})
+}
+
 func TestCreateJwtToken(t *testing.T) {
 	type args struct {
 		user entities.User
@@ -30,7 +92,7 @@ func TestCreateJwtToken(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token := NewToken()
+			token := newTestToken(t)
 			got, err := token.CreateJwtToken(tt.args.user)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateJwtToken() error = %v, wantErr %v", err, tt.wantErr)
@@ -42,3 +104,460 @@ func TestCreateJwtToken(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateJwtToken_KeyId(t *testing.T) {
+	// Arrange
+	token := newTestToken(t)
+
+	// Act
+	got, err := token.CreateJwtToken(entities.User{Id: "1"})
+
+	// Assert
+	assert.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(got, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSymmetricKeyId, parsed.Header["kid"])
+}
+
+func TestVerifyJwtToken(t *testing.T) {
+	config := newConfig(KeyConfig{
+		Algorithm: ALGORITHM_HS256,
+		SecretKey: []byte("key"),
+	})
+	config.Issuer = "lambda-register"
+	config.Audience = []string{"orders", "payments"}
+
+	token, err := NewToken(config, providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	validToken, err := token.CreateJwtToken(entities.User{Id: "1"})
+	if err != nil {
+		t.Fatalf("CreateJwtToken() error = %v", err)
+	}
+
+	newClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"jti": "jti",
+			"sub": "1",
+			"iss": "lambda-register",
+			"aud": []string{"payments"},
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	signWith := func(key []byte, kid string, change func(jwt.MapClaims)) string {
+		claims := newClaims()
+		change(claims)
+
+		unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		unsigned.Header["kid"] = kid
+
+		signed, err := unsigned.SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+
+		return signed
+	}
+
+	noChange := func(jwt.MapClaims) {}
+
+	type args struct {
+		tokenString string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Verify a valid JWT Token",
+			args: args{
+				tokenString: validToken,
+			},
+			want:    "1",
+			wantErr: false,
+		},
+		{
+			name: "Verify a JWT Token with one of the accepted audiences",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, noChange),
+			},
+			want:    "1",
+			wantErr: false,
+		},
+		{
+			name: "Verify an expired JWT Token",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					c["exp"] = time.Now().Add(-time.Minute).Unix()
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token without expiration",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					delete(c, "exp")
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token issued in the future",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					c["iat"] = time.Now().Add(time.Hour).Unix()
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token from another issuer",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					c["iss"] = "another-issuer"
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token for another audience",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					c["aud"] = []string{"another-audience"}
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token without subject",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					delete(c, "sub")
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token without id",
+			args: args{
+				tokenString: signWith([]byte("key"), defaultSymmetricKeyId, func(c jwt.MapClaims) {
+					delete(c, "jti")
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token signed with another key",
+			args: args{
+				tokenString: signWith([]byte("another-key"), defaultSymmetricKeyId, noChange),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a JWT Token with an unknown kid",
+			args: args{
+				tokenString: signWith([]byte("key"), "unknown", noChange),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Verify a malformed JWT Token",
+			args: args{
+				tokenString: "abc",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := token.VerifyJwtToken(context.Background(), tt.args.tokenString)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyJwtToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Subject != tt.want {
+				t.Errorf("VerifyJwtToken().Subject = %v, want %v", got.Subject, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyJwtToken_Asymmetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		config KeyConfig
+	}{
+		{
+			name: "Sign and parse with RS256",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_RS256,
+				PrivateKey: generateRSAPrivateKeyPEM(t),
+			},
+		},
+		{
+			name: "Sign and parse with ES256",
+			config: KeyConfig{
+				Algorithm:  ALGORITHM_ES256,
+				PrivateKey: generateECPrivateKeyPEM(t, elliptic.P256()),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			token, err := NewToken(newConfig(tt.config), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+			assert.NoError(t, err)
+
+			signed, err := token.CreateJwtToken(entities.User{Id: "1"})
+			assert.NoError(t, err)
+
+			// Act
+			got, err := token.VerifyJwtToken(context.Background(), signed)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "1", got.Subject)
+		})
+	}
+}
+
+func TestVerifyJwtToken_RejectsAlgorithmConfusion(t *testing.T) {
+	// Arrange
+	token, err := NewToken(newConfig(KeyConfig{
+		Algorithm:  ALGORITHM_RS256,
+		Id:         "rs-1",
+		PrivateKey: generateRSAPrivateKeyPEM(t),
+	}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+	assert.NoError(t, err)
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "1",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	unsigned.Header["kid"] = "rs-1"
+
+	forged, err := unsigned.SignedString([]byte(token.GetJwks().Keys[0].N))
+	assert.NoError(t, err)
+
+	// Act
+	_, err = token.VerifyJwtToken(context.Background(), forged)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestGetJwks(t *testing.T) {
+	t.Run("Should not publish symmetric keys", func(t *testing.T) {
+		// Arrange
+		token := newTestToken(t)
+
+		// Act
+		got := token.GetJwks()
+
+		// Assert
+		assert.Empty(t, got.Keys)
+	})
+
+	t.Run("Should publish the RSA public key", func(t *testing.T) {
+		// Arrange
+		token, err := NewToken(newConfig(KeyConfig{
+			Algorithm:  ALGORITHM_RS256,
+			Id:         "rs-1",
+			PrivateKey: generateRSAPrivateKeyPEM(t),
+		}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+		assert.NoError(t, err)
+
+		// Act
+		got := token.GetJwks()
+
+		// Assert
+		assert.Len(t, got.Keys, 1)
+		assert.Equal(t, "RSA", got.Keys[0].Kty)
+		assert.Equal(t, "RS256", got.Keys[0].Alg)
+		assert.Equal(t, "rs-1", got.Keys[0].Kid)
+		assert.Equal(t, "AQAB", got.Keys[0].E)
+		assert.NotEmpty(t, got.Keys[0].N)
+	})
+
+	t.Run("Should publish the EC public key", func(t *testing.T) {
+		// Arrange
+		token, err := NewToken(newConfig(KeyConfig{
+			Algorithm:  ALGORITHM_ES256,
+			Id:         "es-1",
+			PrivateKey: generateECPrivateKeyPEM(t, elliptic.P256()),
+		}), providers.NewTimeProvider(time.Now), newRevocationStoreMock(t))
+		assert.NoError(t, err)
+
+		// Act
+		got := token.GetJwks()
+
+		// Assert
+		assert.Len(t, got.Keys, 1)
+		assert.Equal(t, "EC", got.Keys[0].Kty)
+		assert.Equal(t, "ES256", got.Keys[0].Alg)
+		assert.Equal(t, "P-256", got.Keys[0].Crv)
+		assert.Len(t, got.Keys[0].X, 43)
+		assert.Len(t, got.Keys[0].Y, 43)
+	})
+}
+
+func TestCreateJwtToken_Claims(t *testing.T) {
+	now := time.Date(2024, 4, 13, 23, 37, 11, 0, time.UTC)
+
+	timeProvider := providers.NewTimeProvider(func() time.Time {
+		return now
+	})
+
+	config := newConfig(KeyConfig{
+		Algorithm: ALGORITHM_HS256,
+		SecretKey: []byte("key"),
+	})
+	config.Issuer = "https://register.fastfood"
+	config.Audience = []string{"orders", "payments"}
+	config.TTL = time.Minute * 15
+
+	token, err := NewToken(config, timeProvider, newRevocationStoreMock(t))
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	parseClaims := func(t *testing.T, signed string) entities.Claims {
+		var claims entities.Claims
+		if _, _, err := jwt.NewParser().ParseUnverified(signed, &claims); err != nil {
+			t.Fatalf("ParseUnverified() error = %v", err)
+		}
+		return claims
+	}
+
+	t.Run("Should set the registered claims from the configuration", func(t *testing.T) {
+		// Act
+		signed, err := token.CreateJwtToken(entities.User{Id: "1", DocumentId: "218.486.310-65", DocumentType: entities.DOCUMENT_TYPE_CPF})
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := parseClaims(t, signed)
+		assert.Equal(t, "1", claims.Subject)
+		assert.Equal(t, "https://register.fastfood", claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"orders", "payments"}, claims.Audience)
+		assert.Equal(t, now, claims.IssuedAt.Time.UTC())
+		assert.Equal(t, now, claims.NotBefore.Time.UTC())
+		assert.Equal(t, now.Add(time.Minute*15), claims.ExpiresAt.Time.UTC())
+		assert.NotEmpty(t, claims.ID)
+		assert.False(t, claims.IsAnonymous)
+		assert.Equal(t, "218******65", claims.Document)
+	})
+
+	t.Run("Should set a unique jti for every token", func(t *testing.T) {
+		// Act
+		first, err := token.CreateJwtToken(entities.User{Id: "1"})
+		assert.NoError(t, err)
+
+		second, err := token.CreateJwtToken(entities.User{Id: "1"})
+		assert.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, parseClaims(t, first).ID, parseClaims(t, second).ID)
+	})
+
+	t.Run("Should mask the CNPJ of corporate users", func(t *testing.T) {
+		// Act
+		signed, err := token.CreateJwtToken(entities.User{Id: "1", DocumentId: "12.ABC.345/01DE-35", DocumentType: entities.DOCUMENT_TYPE_CNPJ})
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := parseClaims(t, signed)
+		assert.Equal(t, "12**********35", claims.Document)
+	})
+
+	t.Run("Should mask the passport of foreign users", func(t *testing.T) {
+		// Act
+		signed, err := token.CreateJwtToken(entities.User{Id: "1", DocumentId: "USA:X1234567", DocumentType: entities.DOCUMENT_TYPE_PASSPORT})
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := parseClaims(t, signed)
+		assert.Equal(t, "USA:X1****67", claims.Document)
+	})
+
+	t.Run("Should not set the document for anonymous users", func(t *testing.T) {
+		// Act
+		signed, err := token.CreateJwtToken(entities.User{Id: "1", IsAnonymous: true})
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := parseClaims(t, signed)
+		assert.True(t, claims.IsAnonymous)
+		assert.Empty(t, claims.Document)
+	})
+}
+
+func TestVerifyJwtToken_Revocation(t *testing.T) {
+	newTokenWith := func(t *testing.T, revocations *token_interface_mock.MockRevocationStore) Token {
+		token, err := NewToken(newConfig(KeyConfig{
+			Algorithm: ALGORITHM_HS256,
+			SecretKey: []byte("key"),
+		}), providers.NewTimeProvider(time.Now), revocations)
+		if err != nil {
+			t.Fatalf("NewToken() error = %v", err)
+		}
+
+		return token
+	}
+
+	t.Run("Should reject a revoked token", func(t *testing.T) {
+		// Arrange
+		revocations := token_interface_mock.NewMockRevocationStore(t)
+		token := newTokenWith(t, revocations)
+
+		signed, err := token.CreateJwtToken(entities.User{Id: "1"})
+		if err != nil {
+			t.Fatalf("CreateJwtToken() error = %v", err)
+		}
+
+		revocations.On("IsTokenRevoked", mock.Anything, mock.AnythingOfType("string")).
+			Return(true, nil).
+			Once()
+
+		// Act
+		_, err = token.VerifyJwtToken(context.Background(), signed)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		revocations.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the store fails", func(t *testing.T) {
+		// Arrange
+		revocations := token_interface_mock.NewMockRevocationStore(t)
+		token := newTokenWith(t, revocations)
+
+		signed, err := token.CreateJwtToken(entities.User{Id: "1"})
+		if err != nil {
+			t.Fatalf("CreateJwtToken() error = %v", err)
+		}
+
+		revocations.On("IsTokenRevoked", mock.Anything, mock.AnythingOfType("string")).
+			Return(false, errors.New("connection refused")).
+			Once()
+
+		// Act
+		_, err = token.VerifyJwtToken(context.Background(), signed)
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTokenRevoked)
+		revocations.AssertExpectations(t)
+	})
+}
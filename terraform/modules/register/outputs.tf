@@ -0,0 +1,14 @@
+output "authorizer_function_arn" {
+  value       = aws_lambda_function.authorizer_function.arn
+  description = "The ARN of the authorizer lambda function"
+}
+
+output "authorizer_invoke_arn" {
+  value       = aws_lambda_function.authorizer_function.invoke_arn
+  description = "The invoke ARN used by API Gateway to call the authorizer"
+}
+
+output "migrate_function_name" {
+  value       = aws_lambda_function.migrate_function.function_name
+  description = "The name of the lambda function that applies the database migrations"
+}
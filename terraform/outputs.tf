@@ -0,0 +1,9 @@
+output "authorizer_invoke_arn" {
+  value       = module.register.authorizer_invoke_arn
+  description = "The invoke ARN of the API Gateway authorizer shared with the other lambdas"
+}
+
+output "migrate_function_name" {
+  value       = module.register.migrate_function_name
+  description = "The name of the migrate lambda invoked by the pipeline before each deploy"
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentRegistrationWithTheSameDocument(t *testing.T) {
+	t.Setenv("SIGN_KEY", "key")
+
+	const ATTEMPTS = 20
+
+	// Arrange
+	ctx := context.Background()
+
+	postgresContainer, db, err := startPostgres(ctx)
+	if err != nil {
+		t.Fatalf("error starting postgres: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+
+		if err := postgresContainer.Terminate(ctx); err != nil {
+			t.Errorf("error terminating postgres: %v", err)
+		}
+	})
+
+	app := &appFeature{db: db}
+
+	handler, err := app.newHandler()
+	if err != nil {
+		t.Fatalf("error creating the handler: %v", err)
+	}
+
+	req := events.APIGatewayProxyRequest{
+		Body: `{"cpf":"548.644.620-97","pass":"fastfood-2024"}`,
+	}
+
+	start := make(chan struct{})
+	statuses := make([]int, ATTEMPTS)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < ATTEMPTS; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			resp, err := handler.CrateUser(ctx, req)
+			if err != nil {
+				t.Errorf("error registering the user: %v", err)
+				return
+			}
+
+			statuses[i] = resp.StatusCode
+		}(i)
+	}
+
+	// Act
+	close(start)
+	wg.Wait()
+
+	// Assert
+	registered, rejected := 0, 0
+
+	for _, status := range statuses {
+		switch status {
+		case http.StatusOK:
+			registered++
+		case http.StatusUnauthorized:
+			rejected++
+		}
+	}
+
+	assert.Equal(t, 1, registered)
+	assert.Equal(t, ATTEMPTS-1, rejected)
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(c.id) FROM customers c WHERE c.document_id = $1;", "548.644.620-97").Scan(&count); err != nil {
+		t.Fatalf("error counting the customers: %v", err)
+	}
+
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/lambda-register/internal/database"
+	"github.com/jfelipearaujo-org/lambda-register/internal/entities"
+	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheSameDocumentNumberWithDifferentTypes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	postgresContainer, db, err := startPostgres(ctx)
+	if err != nil {
+		t.Fatalf("error starting postgres: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+
+		if err := postgresContainer.Terminate(ctx); err != nil {
+			t.Errorf("error terminating postgres: %v", err)
+		}
+	})
+
+	repository := database.NewDatabase(db, providers.NewTimeProvider(time.Now))
+
+	// Act
+	cnpjErr := repository.PersistUser(ctx, entities.NewUser("12ABC34501DE35", entities.DOCUMENT_TYPE_CNPJ, "hash"))
+	passportErr := repository.PersistUser(ctx, entities.NewUser("USA:X1234567", entities.DOCUMENT_TYPE_PASSPORT, "hash"))
+	otherCountryErr := repository.PersistUser(ctx, entities.NewUser("CAN:X1234567", entities.DOCUMENT_TYPE_PASSPORT, "hash"))
+	duplicateErr := repository.PersistUser(ctx, entities.NewUser("USA:X1234567", entities.DOCUMENT_TYPE_PASSPORT, "hash"))
+
+	// Assert
+	assert.NoError(t, cnpjErr)
+	assert.NoError(t, passportErr)
+	assert.NoError(t, otherCountryErr)
+	assert.ErrorIs(t, duplicateErr, entities.ErrDocumentAlreadyInUse)
+}
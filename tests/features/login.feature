@@ -0,0 +1,19 @@
+Feature: login
+    In order to access my account
+    As a registered customer
+    I need to be able to login with my CPF and password
+
+    Scenario: Login with valid credentials
+        Given the user CPF is "548.644.620-97"
+        And the user password is "fastfood-2024"
+        And the user is registered
+        When the user request to login
+        Then the user should be logged in successfully
+
+    Scenario: Login with a wrong password
+        Given the user CPF is "548.644.620-97"
+        And the user password is "fastfood-2024"
+        And the user is registered
+        And the user password is "fastfood-2025"
+        When the user request to login
+        Then the user should not be logged in
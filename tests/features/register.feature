@@ -5,7 +5,7 @@ Feature: register
 
     Scenario: Register a non anonymous user
         Given the user CPF is "548.644.620-97"
-        And the user password is "12345678"
+        And the user password is "fastfood-2024"
         When the user request to be registered
         Then the user should be registered successfully
 
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +16,8 @@ import (
 	"github.com/jfelipearaujo-org/lambda-register/internal/database"
 	"github.com/jfelipearaujo-org/lambda-register/internal/handlers"
 	"github.com/jfelipearaujo-org/lambda-register/internal/hashs"
+	"github.com/jfelipearaujo-org/lambda-register/internal/migrations"
+	"github.com/jfelipearaujo-org/lambda-register/internal/policy"
 	"github.com/jfelipearaujo-org/lambda-register/internal/providers"
 	"github.com/jfelipearaujo-org/lambda-register/internal/token"
 	"github.com/testcontainers/testcontainers-go"
@@ -99,18 +100,80 @@ func (af *appFeature) theUserPasswordIs(ctx context.Context, password string) (c
 	return setPassword(ctx, password), nil
 }
 
-func (af *appFeature) theUserRequestToBeRegistered(ctx context.Context) (context.Context, error) {
+func (af *appFeature) newHandler() (handlers.Handler, error) {
 	timeProvider := providers.NewTimeProvider(time.Now)
 	db := database.NewDatabase(af.db, timeProvider)
-	hasher := hashs.NewHasher()
-	jwt := token.NewToken()
-	handler := handlers.NewHandler(db, hasher, jwt)
+	hashConfig, err := hashs.NewConfigFromEnv()
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	hasher, err := hashs.NewHasher(hashConfig)
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	policyConfig, err := policy.NewConfigFromEnv()
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	passwordPolicy, err := policy.NewPolicy(policyConfig)
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	tokenConfig, err := token.NewConfigFromEnv()
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	jwt, err := token.NewToken(tokenConfig, timeProvider, db)
+	if err != nil {
+		return handlers.Handler{}, err
+	}
+
+	return handlers.NewHandler(db, hasher, jwt, timeProvider, passwordPolicy, handlers.IntrospectionClients{}), nil
+}
+
+func (af *appFeature) theUserRequestToBeRegistered(ctx context.Context) (context.Context, error) {
+	handler, err := af.newHandler()
+	if err != nil {
+		return ctx, err
+	}
+
+	req := events.APIGatewayProxyRequest{
+		Body: fmt.Sprintf(`{"cpf":"%v","pass":"%v"}`, getCPF(ctx), getPassword(ctx)),
+	}
+
+	resp, err := handler.CrateUser(context.Background(), req)
+	if err != nil {
+		return ctx, err
+	}
+
+	return setResponseStatus(ctx, resp.StatusCode), nil
+}
+
+func (af *appFeature) theUserIsRegistered(ctx context.Context) (context.Context, error) {
+	ctx, err := af.theUserRequestToBeRegistered(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return af.theUserShouldBeRegisteredSuccessfully(ctx)
+}
+
+func (af *appFeature) theUserRequestToLogin(ctx context.Context) (context.Context, error) {
+	handler, err := af.newHandler()
+	if err != nil {
+		return ctx, err
+	}
 
 	req := events.APIGatewayProxyRequest{
 		Body: fmt.Sprintf(`{"cpf":"%v","pass":"%v"}`, getCPF(ctx), getPassword(ctx)),
 	}
 
-	resp, err := handler.CrateUser(req)
+	resp, err := handler.Login(context.Background(), req)
 	if err != nil {
 		return ctx, err
 	}
@@ -118,6 +181,24 @@ func (af *appFeature) theUserRequestToBeRegistered(ctx context.Context) (context
 	return setResponseStatus(ctx, resp.StatusCode), nil
 }
 
+func (af *appFeature) theUserShouldBeLoggedInSuccessfully(ctx context.Context) (context.Context, error) {
+	responseStatus := getResponseStatus(ctx)
+	if responseStatus != http.StatusOK {
+		return ctx, fmt.Errorf("expected status code 200, but got %d", responseStatus)
+	}
+
+	return ctx, nil
+}
+
+func (af *appFeature) theUserShouldNotBeLoggedIn(ctx context.Context) (context.Context, error) {
+	responseStatus := getResponseStatus(ctx)
+	if responseStatus != http.StatusUnauthorized {
+		return ctx, fmt.Errorf("expected status code 401, but got %d", responseStatus)
+	}
+
+	return ctx, nil
+}
+
 func (af *appFeature) theUserShouldBeRegisteredSuccessfully(ctx context.Context) (context.Context, error) {
 	responseStatus := getResponseStatus(ctx)
 	if responseStatus != http.StatusOK {
@@ -131,38 +212,59 @@ var (
 	containers = make(map[string]testcontainers.Container)
 )
 
+// startPostgres runs a disposable Postgres with the schema of the embedded
+// migrations applied
+func startPostgres(ctx context.Context) (testcontainers.Container, *sql.DB, error) {
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16"),
+		postgres.WithDatabase("lambda"),
+		postgres.WithUsername("lambda"),
+		postgres.WithPassword("123456"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, nil, err
+	}
+
+	embedded, err := migrations.Embedded()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := migrations.NewMigrator(db, providers.NewTimeProvider(time.Now), embedded).Up(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return postgresContainer, db, nil
+}
+
 func InitializeScenario(ctx *godog.ScenarioContext) {
 	app := &appFeature{}
 
 	ctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
-		postgresContainer, err := postgres.RunContainer(ctx,
-			testcontainers.WithImage("postgres:16"),
-			postgres.WithInitScripts(filepath.Join("testdata", "init-db.sql")),
-			postgres.WithDatabase("lambda"),
-			postgres.WithUsername("lambda"),
-			postgres.WithPassword("123456"),
-			testcontainers.WithWaitStrategy(
-				wait.ForLog("database system is ready to accept connections").
-					WithOccurrence(2).
-					WithStartupTimeout(5*time.Second)),
-		)
-		if err != nil {
-			return ctx, err
-		}
-
-		connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+		postgresContainer, db, err := startPostgres(ctx)
 		if err != nil {
 			return ctx, err
 		}
 
-		app.db, err = sql.Open("postgres", connStr)
-		if err != nil {
-			return ctx, err
-		}
-
-		if err := app.db.Ping(); err != nil {
-			return ctx, err
-		}
+		app.db = db
 
 		containers[sc.Id] = postgresContainer
 
@@ -173,6 +275,10 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the user password is "([^"]*)"$`, app.theUserPasswordIs)
 	ctx.Step(`^the user request to be registered$`, app.theUserRequestToBeRegistered)
 	ctx.Step(`^the user should be registered successfully$`, app.theUserShouldBeRegisteredSuccessfully)
+	ctx.Step(`^the user is registered$`, app.theUserIsRegistered)
+	ctx.Step(`^the user request to login$`, app.theUserRequestToLogin)
+	ctx.Step(`^the user should be logged in successfully$`, app.theUserShouldBeLoggedInSuccessfully)
+	ctx.Step(`^the user should not be logged in$`, app.theUserShouldNotBeLoggedIn)
 
 	ctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
 		if err != nil {